@@ -0,0 +1,299 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+const (
+	// addressBookFileExt 지갑 파일 옆에 저장하는 암호화된 주소록 파일 확장자
+	addressBookFileExt = ".contacts"
+
+	// contactNameMaxLength 연락처 이름 최대 길이 (문자 수)
+	contactNameMaxLength = 100
+
+	// contactNotesMaxLength 연락처 메모 최대 길이 (문자 수)
+	contactNotesMaxLength = 1000
+)
+
+// Contact 주소록 연락처
+type Contact struct {
+	ID        string `json:"id"`        // 연락처 ID (추가 시 발급)
+	Name      string `json:"name"`      // 이름
+	Address   string `json:"address"`   // 비트코인 주소
+	Network   string `json:"network"`   // 네트워크 (mainnet, testnet, signet, regtest, 비어있으면 mainnet)
+	Notes     string `json:"notes"`     // 메모
+	CreatedAt string `json:"createdAt"` // 추가 시각 (RFC3339)
+	UpdatedAt string `json:"updatedAt"` // 마지막 수정 시각 (RFC3339)
+}
+
+// AddressBookRequest 주소록 요청 구조체 (지갑 파일과 비밀번호로 주소록 파일을 암호화/복호화)
+type AddressBookRequest struct {
+	FilePath string  `json:"filePath"` // 지갑 파일 경로
+	Password string  `json:"password"` // 지갑 비밀번호
+	Contact  Contact `json:"contact"`  // 추가/수정할 연락처 (수정/삭제 시 ID 필수, 목록 조회 시 무시)
+}
+
+// AddressBookResponse 주소록 응답 구조체
+type AddressBookResponse struct {
+	Success   bool      `json:"success"`   // 성공 여부
+	Message   string    `json:"message"`   // 응답 메시지
+	ErrorCode string    `json:"errorCode"` // 에러 코드 (다국어 처리용)
+	Contact   Contact   `json:"contact"`   // 추가/수정된 연락처
+	Contacts  []Contact `json:"contacts"`  // 전체 연락처 목록 (이름순)
+}
+
+// loadAddressBook 지갑 비밀번호로 주소록 파일 복호화 (파일이 없으면 빈 목록)
+func loadAddressBook(walletPath, password string) ([]Contact, error) {
+	if err := verifyWalletPassword(walletPath, password); err != nil {
+		return nil, err
+	}
+
+	fileData, err := os.ReadFile(walletSidecarPath(walletPath, addressBookFileExt))
+	if os.IsNotExist(err) {
+		return []Contact{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("주소록 파일을 읽을 수 없습니다: %v", err)
+	}
+
+	plaintext, err := decryptColdWalletData(fileData, password)
+	if err != nil {
+		return nil, fmt.Errorf("주소록 파일 복호화 실패: %v", err)
+	}
+	var contacts []Contact
+	if err := json.Unmarshal(plaintext, &contacts); err != nil {
+		return nil, fmt.Errorf("주소록 파일 파싱 실패: %v", err)
+	}
+	return contacts, nil
+}
+
+// saveAddressBook 주소록을 지갑 비밀번호로 암호화하여 저장
+func saveAddressBook(walletPath, password string, contacts []Contact) error {
+	sort.SliceStable(contacts, func(i, j int) bool {
+		return strings.ToLower(contacts[i].Name) < strings.ToLower(contacts[j].Name)
+	})
+
+	plaintext, err := json.Marshal(contacts)
+	if err != nil {
+		return err
+	}
+	fileData, err := encryptColdWalletData(plaintext, password)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(walletSidecarPath(walletPath, addressBookFileExt), fileData, 0600); err != nil {
+		return fmt.Errorf("주소록 파일 저장 실패: %v", err)
+	}
+	return nil
+}
+
+// normalizeContact 연락처 입력값 정리 및 검증 (주소는 연락처 네트워크 기준으로 확인)
+func normalizeContact(contact Contact) (Contact, error) {
+	contact.Name = strings.TrimSpace(contact.Name)
+	contact.Address = strings.TrimSpace(contact.Address)
+	contact.Notes = strings.TrimSpace(contact.Notes)
+	if contact.Network == "" {
+		contact.Network = "mainnet"
+	}
+
+	if contact.Name == "" {
+		return contact, fmt.Errorf("이름을 입력해주세요")
+	}
+	if len([]rune(contact.Name)) > contactNameMaxLength {
+		return contact, fmt.Errorf("이름은 %d자를 넘을 수 없습니다", contactNameMaxLength)
+	}
+	if len([]rune(contact.Notes)) > contactNotesMaxLength {
+		return contact, fmt.Errorf("메모는 %d자를 넘을 수 없습니다", contactNotesMaxLength)
+	}
+
+	params, err := p2pNetworkParams(contact.Network)
+	if err != nil {
+		return contact, err
+	}
+	address, err := btcutil.DecodeAddress(contact.Address, params)
+	if err != nil || !address.IsForNet(params) {
+		return contact, fmt.Errorf("%s 네트워크의 올바른 주소가 아닙니다: %s", contact.Network, contact.Address)
+	}
+	// bech32 주소는 대소문자를 섞지 않은 형태로 저장하여 중복 확인이 일관되도록 함
+	contact.Address = address.EncodeAddress()
+	return contact, nil
+}
+
+// findContact 연락처 ID의 목록 위치 (없으면 -1)
+func findContact(contacts []Contact, id string) int {
+	for i, contact := range contacts {
+		if contact.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// addressBookFailure 주소록 오류 응답
+func addressBookFailure(err error, code string) AddressBookResponse {
+	return AddressBookResponse{
+		Success:   false,
+		Message:   err.Error(),
+		ErrorCode: code,
+	}
+}
+
+// ListContacts 주소록의 모든 연락처 조회
+func (a *App) ListContacts(request AddressBookRequest) AddressBookResponse {
+	contacts, err := loadAddressBook(request.FilePath, request.Password)
+	if err != nil {
+		return addressBookFailure(err, "ADDRESS_BOOK_UNAVAILABLE")
+	}
+
+	return AddressBookResponse{
+		Success:  true,
+		Message:  "성공",
+		Contacts: contacts,
+	}
+}
+
+// AddContact 주소록에 연락처 추가 (같은 네트워크의 같은 주소는 한 번만 저장)
+func (a *App) AddContact(request AddressBookRequest) AddressBookResponse {
+	contact, err := normalizeContact(request.Contact)
+	if err != nil {
+		return addressBookFailure(err, "CONTACT_INVALID")
+	}
+
+	contacts, err := loadAddressBook(request.FilePath, request.Password)
+	if err != nil {
+		return addressBookFailure(err, "ADDRESS_BOOK_UNAVAILABLE")
+	}
+	for _, existing := range contacts {
+		if existing.Address == contact.Address && existing.Network == contact.Network {
+			return addressBookFailure(fmt.Errorf("이미 주소록에 있는 주소입니다 (%s)", existing.Name), "CONTACT_DUPLICATE")
+		}
+	}
+
+	idBytes := make([]byte, 8)
+	if _, err := rand.Read(idBytes); err != nil {
+		return addressBookFailure(fmt.Errorf("연락처 ID 생성 실패: %v", err), "ADDRESS_BOOK_UNAVAILABLE")
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	contact.ID = hex.EncodeToString(idBytes)
+	contact.CreatedAt = now
+	contact.UpdatedAt = now
+
+	contacts = append(contacts, contact)
+	if err := saveAddressBook(request.FilePath, request.Password, contacts); err != nil {
+		return addressBookFailure(err, "ADDRESS_BOOK_UNAVAILABLE")
+	}
+
+	return AddressBookResponse{
+		Success:  true,
+		Message:  "연락처가 추가되었습니다",
+		Contact:  contact,
+		Contacts: contacts,
+	}
+}
+
+// UpdateContact 연락처 이름/주소/네트워크/메모 수정
+func (a *App) UpdateContact(request AddressBookRequest) AddressBookResponse {
+	contact, err := normalizeContact(request.Contact)
+	if err != nil {
+		return addressBookFailure(err, "CONTACT_INVALID")
+	}
+
+	contacts, err := loadAddressBook(request.FilePath, request.Password)
+	if err != nil {
+		return addressBookFailure(err, "ADDRESS_BOOK_UNAVAILABLE")
+	}
+	index := findContact(contacts, contact.ID)
+	if index < 0 {
+		return addressBookFailure(fmt.Errorf("연락처를 찾을 수 없습니다"), "CONTACT_NOT_FOUND")
+	}
+	for i, existing := range contacts {
+		if i != index && existing.Address == contact.Address && existing.Network == contact.Network {
+			return addressBookFailure(fmt.Errorf("이미 주소록에 있는 주소입니다 (%s)", existing.Name), "CONTACT_DUPLICATE")
+		}
+	}
+
+	contact.CreatedAt = contacts[index].CreatedAt
+	contact.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	contacts[index] = contact
+	if err := saveAddressBook(request.FilePath, request.Password, contacts); err != nil {
+		return addressBookFailure(err, "ADDRESS_BOOK_UNAVAILABLE")
+	}
+
+	return AddressBookResponse{
+		Success:  true,
+		Message:  "연락처가 수정되었습니다",
+		Contact:  contact,
+		Contacts: contacts,
+	}
+}
+
+// DeleteContact 주소록에서 연락처 삭제
+func (a *App) DeleteContact(request AddressBookRequest) AddressBookResponse {
+	contacts, err := loadAddressBook(request.FilePath, request.Password)
+	if err != nil {
+		return addressBookFailure(err, "ADDRESS_BOOK_UNAVAILABLE")
+	}
+	index := findContact(contacts, request.Contact.ID)
+	if index < 0 {
+		return addressBookFailure(fmt.Errorf("연락처를 찾을 수 없습니다"), "CONTACT_NOT_FOUND")
+	}
+
+	contacts = append(contacts[:index], contacts[index+1:]...)
+	if err := saveAddressBook(request.FilePath, request.Password, contacts); err != nil {
+		return addressBookFailure(err, "ADDRESS_BOOK_UNAVAILABLE")
+	}
+
+	return AddressBookResponse{
+		Success:  true,
+		Message:  "연락처가 삭제되었습니다",
+		Contacts: contacts,
+	}
+}
+
+// addressBookWarnings 주소록에 없는 받는 주소에 대한 경고 생성
+// 지갑 파일이 지정되지 않으면 확인하지 않음 (주소록을 열 수 없으면 그 사실을 경고로 알림)
+func addressBookWarnings(request SendBitcoinRequest, outputs []TransactionPlanOutput) []TransactionWarning {
+	if request.WalletFilePath == "" {
+		return nil
+	}
+
+	contacts, err := loadAddressBook(request.WalletFilePath, request.WalletPassword)
+	if err != nil {
+		return []TransactionWarning{{
+			Code:    "ADDRESS_BOOK_UNAVAILABLE",
+			Message: fmt.Sprintf("주소록을 확인할 수 없습니다: %v", err),
+		}}
+	}
+
+	known := make(map[string]bool, len(contacts))
+	for _, contact := range contacts {
+		if contact.Network == "mainnet" {
+			known[contact.Address] = true
+		}
+	}
+
+	var warnings []TransactionWarning
+	for _, output := range outputs {
+		if output.Type != "recipient" || output.Address == request.WalletData.Address {
+			continue
+		}
+		if address, err := btcutil.DecodeAddress(output.Address, &chaincfg.MainNetParams); err == nil && known[address.EncodeAddress()] {
+			continue
+		}
+		warnings = append(warnings, TransactionWarning{
+			Code:    "RECIPIENT_NOT_IN_ADDRESS_BOOK",
+			Message: fmt.Sprintf("주소록에 없는 새 주소입니다: %s", output.Address),
+		})
+	}
+	return warnings
+}
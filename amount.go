@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil"
+)
+
+// 금액 단위 (소수점 자릿수: BTC 8, mBTC 5, bits 2, sats 0)
+const (
+	UnitBTC  = "BTC"
+	UnitMBTC = "mBTC"
+	UnitBits = "bits"
+	UnitSats = "sats"
+)
+
+// ParseAmountResponse 금액 문자열 변환 응답 구조체
+type ParseAmountResponse struct {
+	Success   bool   `json:"success"`   // 성공 여부
+	Message   string `json:"message"`   // 응답 메시지
+	ErrorCode string `json:"errorCode"` // 에러 코드 (다국어 처리용)
+	AmountSat int64  `json:"amountSat"` // 변환된 금액 (satoshi)
+}
+
+// normalizeUnit 단위 문자열을 표준 단위로 변환하고 소수점 자릿수 반환
+func normalizeUnit(unit string) (string, int, error) {
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case "", "btc":
+		return UnitBTC, 8, nil
+	case "mbtc":
+		return UnitMBTC, 5, nil
+	case "bits", "bit", "ubtc", "µbtc":
+		return UnitBits, 2, nil
+	case "sats", "sat", "satoshi", "satoshis":
+		return UnitSats, 0, nil
+	}
+	return "", 0, fmt.Errorf("지원되지 않는 금액 단위: %s", unit)
+}
+
+// parseAmount 10진수 금액 문자열을 satoshi로 변환 (부동소수점 없이 문자열 기반으로 계산)
+// 값 뒤에 단위가 붙어있으면 ("0.01 BTC", "500sats") defaultUnit 대신 해당 단위 사용
+func parseAmount(value, defaultUnit string) (int64, error) {
+	value = strings.TrimSpace(value)
+
+	// 숫자 부분과 단위 부분 분리
+	end := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	number, unit := value, defaultUnit
+	if end >= 0 {
+		number, unit = value[:end], value[end:]
+	}
+
+	_, decimals, err := normalizeUnit(unit)
+	if err != nil {
+		return 0, err
+	}
+
+	if number == "" || number == "." {
+		return 0, fmt.Errorf("잘못된 금액 형식: %q", value)
+	}
+
+	intPart, fracPart := number, ""
+	if dot := strings.IndexByte(number, '.'); dot >= 0 {
+		intPart, fracPart = number[:dot], number[dot+1:]
+		if strings.IndexByte(fracPart, '.') >= 0 {
+			return 0, fmt.Errorf("잘못된 금액 형식: %q", value)
+		}
+	}
+
+	// 단위가 허용하는 자릿수보다 세밀한 값은 거부 (1 satoshi 미만)
+	trimmedFrac := strings.TrimRight(fracPart, "0")
+	if len(trimmedFrac) > decimals {
+		return 0, fmt.Errorf("소수점 이하 자릿수가 너무 많습니다: %q", value)
+	}
+	fracPart = trimmedFrac + strings.Repeat("0", decimals-len(trimmedFrac))
+
+	// 21,000,000 BTC를 넘는 값은 자릿수 단계에서 거부하여 오버플로 방지
+	digits := strings.TrimLeft(intPart+fracPart, "0")
+	if len(digits) > 16 {
+		return 0, fmt.Errorf("금액이 너무 큽니다: %q", value)
+	}
+
+	var sat int64
+	for _, c := range digits {
+		sat = sat*10 + int64(c-'0')
+	}
+
+	if sat > btcutil.MaxSatoshi {
+		return 0, fmt.Errorf("금액이 너무 큽니다: %q", value)
+	}
+
+	return sat, nil
+}
+
+// formatAmount satoshi 금액을 지정한 단위의 10진수 문자열로 변환
+func formatAmount(sat int64, unit string) (string, error) {
+	_, decimals, err := normalizeUnit(unit)
+	if err != nil {
+		return "", err
+	}
+
+	sign := ""
+	if sat < 0 {
+		sign = "-"
+		sat = -sat
+	}
+
+	digits := fmt.Sprintf("%0*d", decimals+1, sat)
+	if decimals == 0 {
+		return sign + digits, nil
+	}
+
+	split := len(digits) - decimals
+	return sign + digits[:split] + "." + digits[split:], nil
+}
+
+// ParseAmount 사용자가 입력한 금액 문자열을 satoshi로 변환
+func (a *App) ParseAmount(value, unit string) ParseAmountResponse {
+	sat, err := parseAmount(value, unit)
+	if err != nil {
+		return ParseAmountResponse{
+			Success:   false,
+			Message:   err.Error(),
+			ErrorCode: "AMOUNT_INVALID",
+		}
+	}
+
+	return ParseAmountResponse{
+		Success:   true,
+		Message:   "성공",
+		AmountSat: sat,
+	}
+}
+
+// FormatAmount satoshi 금액을 지정한 단위 문자열로 변환 (지원하지 않는 단위는 빈 문자열)
+func (a *App) FormatAmount(sat int64, unit string) string {
+	formatted, err := formatAmount(sat, unit)
+	if err != nil {
+		return ""
+	}
+	return formatted
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil"
+)
+
+func TestAmountRoundTrip(t *testing.T) {
+	amounts := []struct {
+		name string
+		sat  int64
+	}{
+		{"zero", 0},
+		{"one sat", 1},
+		{"0.29 BTC", 29_000_000},
+		{"20999999.99999999 BTC", 2_099_999_999_999_999},
+		{"21M BTC", btcutil.MaxSatoshi},
+	}
+	units := []string{UnitBTC, UnitMBTC, UnitBits, UnitSats}
+
+	for _, amount := range amounts {
+		for _, unit := range units {
+			formatted, err := formatAmount(amount.sat, unit)
+			if err != nil {
+				t.Fatalf("%s/%s: formatAmount: %v", amount.name, unit, err)
+			}
+			sat, err := parseAmount(formatted, unit)
+			if err != nil {
+				t.Fatalf("%s/%s: parseAmount(%q): %v", amount.name, unit, formatted, err)
+			}
+			if sat != amount.sat {
+				t.Errorf("%s/%s: round trip %q = %d, want %d", amount.name, unit, formatted, sat, amount.sat)
+			}
+		}
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		value string
+		unit  string
+		want  int64
+	}{
+		{"0", UnitBTC, 0},
+		{"0.00000001", UnitBTC, 1},
+		{"0.29", UnitBTC, 29_000_000},
+		{"0.29000000", UnitBTC, 29_000_000},
+		{".5", UnitBTC, 50_000_000},
+		{"20999999.99999999", UnitBTC, 2_099_999_999_999_999},
+		{"21000000", UnitBTC, btcutil.MaxSatoshi},
+		{"0.00001", UnitMBTC, 1},
+		{"290", UnitMBTC, 29_000_000},
+		{"0.01", UnitBits, 1},
+		{"290000", UnitBits, 29_000_000},
+		{"1", UnitSats, 1},
+		{"2100000000000000", UnitSats, btcutil.MaxSatoshi},
+		{"0.01 BTC", UnitSats, 1_000_000},
+		{"500sats", UnitBTC, 500},
+		{"1.5 mBTC", UnitBTC, 150_000},
+	}
+
+	for _, tt := range tests {
+		got, err := parseAmount(tt.value, tt.unit)
+		if err != nil {
+			t.Errorf("parseAmount(%q, %q): %v", tt.value, tt.unit, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseAmount(%q, %q) = %d, want %d", tt.value, tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestParseAmountRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		unit  string
+	}{
+		{"more than 8 decimals", "0.000000001", UnitBTC},
+		{"sub-sat mBTC", "0.000001", UnitMBTC},
+		{"sub-sat bits", "0.001", UnitBits},
+		{"fractional sats", "1.5", UnitSats},
+		{"over 21M", "21000000.00000001", UnitBTC},
+		{"far over 21M", "99999999999", UnitBTC},
+		{"over 21M sats", "2100000000000001", UnitSats},
+		{"negative", "-1", UnitBTC},
+		{"negative sats", "-0.5", UnitSats},
+		{"exponent", "1e3", UnitBTC},
+		{"exponent with sign", "1E-8", UnitBTC},
+		{"unknown unit", "1", "doge"},
+		{"unknown suffix", "1 eth", UnitBTC},
+		{"empty", "", UnitBTC},
+		{"lone dot", ".", UnitBTC},
+		{"two dots", "1.2.3", UnitBTC},
+	}
+
+	for _, tt := range tests {
+		if got, err := parseAmount(tt.value, tt.unit); err == nil {
+			t.Errorf("%s: parseAmount(%q, %q) = %d, want error", tt.name, tt.value, tt.unit, got)
+		}
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		sat  int64
+		unit string
+		want string
+	}{
+		{0, UnitBTC, "0.00000000"},
+		{1, UnitBTC, "0.00000001"},
+		{29_000_000, UnitBTC, "0.29000000"},
+		{btcutil.MaxSatoshi, UnitBTC, "21000000.00000000"},
+		{1, UnitMBTC, "0.00001"},
+		{1, UnitBits, "0.01"},
+		{1, UnitSats, "1"},
+		{-1, UnitBTC, "-0.00000001"},
+	}
+
+	for _, tt := range tests {
+		got, err := formatAmount(tt.sat, tt.unit)
+		if err != nil {
+			t.Errorf("formatAmount(%d, %q): %v", tt.sat, tt.unit, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("formatAmount(%d, %q) = %q, want %q", tt.sat, tt.unit, got, tt.want)
+		}
+	}
+
+	if _, err := formatAmount(1, "doge"); err == nil {
+		t.Error("formatAmount with unknown unit should fail")
+	}
+}
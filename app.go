@@ -10,13 +10,13 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"math"
 	"os"
 	"path/filepath"
 	goruntime "runtime"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 
@@ -36,6 +36,14 @@ import (
 // App struct
 type App struct {
 	ctx context.Context
+
+	configMu sync.RWMutex // 설정 및 백엔드 보호
+	config   AppConfig    // 앱 설정
+	backend  ChainBackend // 블록체인 조회/전송 백엔드
+
+	pendingMu  sync.Mutex                     // 확인 대기 거래 보호
+	pendingTxs map[string]*pendingTransaction // 확인 토큰별 서명 전 거래 계획
+	sentChange map[string]int                 // 이 앱에서 보낸 거래의 거스름돈 출력 위치 (없으면 -1, 수수료 올리기용)
 }
 
 // WalletData 지갑 정보를 저장하는 구조체 (coldwallet 호환)
@@ -94,12 +102,23 @@ type PasswordValidation struct {
 
 // NewApp 새로운 App 애플리케이션 구조체 생성
 func NewApp() *App {
-	return &App{}
+	config := defaultAppConfig()
+	return &App{
+		config:     config,
+		backend:    newCachedBackend(newEsploraBackend(config.Backend.URL, newHTTPFetcher(context.Background(), newHTTPClient(directDial)))),
+		pendingTxs: make(map[string]*pendingTransaction),
+		sentChange: make(map[string]int),
+	}
 }
 
 // Startup 앱 시작시 호출되는 함수, 컨텍스트 저장
 func (a *App) Startup(ctx context.Context) {
 	a.ctx = ctx
+
+	// 저장된 설정 적용 (잘못된 백엔드 설정이면 프록시 설정을 따르는 기본 백엔드 사용)
+	if err := a.applyStartupConfig(loadAppConfig()); err != nil {
+		fmt.Printf("설정 적용 실패: %v\n", err)
+	}
 }
 
 // Greet 인사말 반환 함수 (테스트용)
@@ -437,19 +456,60 @@ func (a *App) saveColdWallet(walletData WalletData, password, walletName, savePa
 		return "", err
 	}
 
+	fileData, err := encryptColdWalletData(walletJSON, password)
+	if err != nil {
+		return "", err
+	}
+
+	// 저장 경로 결정
+	saveDir := savePath
+	if saveDir == "" {
+		saveDir = defaultSaveDirectory()
+	}
+
+	// 파일명 생성 (공백을 언더스코어로 치환 및 중복 방지)
+	baseName := strings.ReplaceAll(walletName, " ", "_")
+	filename := baseName + ".wallet"
+	filePath := filepath.Join(saveDir, filename)
+
+	// 파일이 이미 존재하면 번호를 추가하여 중복 방지
+	counter := 1
+	for {
+		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+			// 파일이 존재하지 않으면 사용 가능
+			break
+		}
+		// 파일이 존재하면 번호 추가
+		filename = fmt.Sprintf("%s(%d).wallet", baseName, counter)
+		filePath = filepath.Join(saveDir, filename)
+		counter++
+	}
+
+	// 파일 저장 (소유자만 읽기/쓰기 권한)
+	err = os.WriteFile(filePath, fileData, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	return filePath, nil
+}
+
+// encryptColdWalletData coldwallet 호환 방식(PBKDF2 + AES-256-CBC)으로 데이터를 암호화하여 파일 내용 반환
+// 지갑 파일과 지갑에 딸린 라벨 등의 부가 데이터 파일이 같은 형식을 사용
+func encryptColdWalletData(data []byte, password string) ([]byte, error) {
 	// 체크섬 계산
-	checksum := sha256.Sum256(walletJSON)
+	checksum := sha256.Sum256(data)
 
 	// Salt 및 IV 생성 (32바이트 salt, 16바이트 IV)
 	salt := make([]byte, 32)
 	iv := make([]byte, 16)
-	_, err = rand.Read(salt)
+	_, err := rand.Read(salt)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	_, err = rand.Read(iv)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// PBKDF2로 키 생성 (coldwallet와 동일)
@@ -462,11 +522,11 @@ func (a *App) saveColdWallet(walletData WalletData, password, walletName, savePa
 	// AES 암호화를 위한 cipher 생성
 	block, err := aes.NewCipher(key)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// PKCS7 패딩 추가
-	paddedData := pkcs7Pad(walletJSON, aes.BlockSize)
+	paddedData := pkcs7Pad(data, aes.BlockSize)
 
 	// createCipher는 내부적으로 랜덤 IV를 생성하지만
 	// 여기서는 미리 생성된 IV를 사용
@@ -495,54 +555,19 @@ func (a *App) saveColdWallet(walletData WalletData, password, walletName, savePa
 	}
 
 	// JSON으로 직렬화
-	fileData, err := json.Marshal(fileFormat)
-	if err != nil {
-		return "", err
-	}
-
-	// 저장 경로 결정
-	var saveDir string
-	if savePath != "" {
-		saveDir = savePath
-	} else {
-		// 기본 저장 경로 (폴더를 선택하지 않은 경우)
-		if goruntime.GOOS == "windows" {
-			saveDir = "C:\\"
-		} else {
-			homeDir, err := os.UserHomeDir()
-			if err != nil {
-				saveDir = "."
-			} else {
-				saveDir = homeDir
-			}
-		}
-	}
-
-	// 파일명 생성 (공백을 언더스코어로 치환 및 중복 방지)
-	baseName := strings.ReplaceAll(walletName, " ", "_")
-	filename := baseName + ".wallet"
-	filePath := filepath.Join(saveDir, filename)
+	return json.Marshal(fileFormat)
+}
 
-	// 파일이 이미 존재하면 번호를 추가하여 중복 방지
-	counter := 1
-	for {
-		if _, err := os.Stat(filePath); os.IsNotExist(err) {
-			// 파일이 존재하지 않으면 사용 가능
-			break
-		}
-		// 파일이 존재하면 번호 추가
-		filename = fmt.Sprintf("%s(%d).wallet", baseName, counter)
-		filePath = filepath.Join(saveDir, filename)
-		counter++
+// defaultSaveDirectory 폴더를 선택하지 않은 경우 사용할 기본 저장 경로
+func defaultSaveDirectory() string {
+	if goruntime.GOOS == "windows" {
+		return "C:\\"
 	}
-
-	// 파일 저장 (소유자만 읽기/쓰기 권한)
-	err = os.WriteFile(filePath, fileData, 0600)
+	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		return "", err
+		return "."
 	}
-
-	return filePath, nil
+	return homeDir
 }
 
 // pkcs7Pad 데이터에 PKCS7 패딩을 추가
@@ -649,27 +674,44 @@ func (a *App) OpenWallet(request CheckWalletRequest) OpenWalletResponse {
 
 // decryptColdWallet coldwallet 호환 방식으로 지갑 데이터 복호화
 func (a *App) decryptColdWallet(fileData []byte, password string) (WalletData, error) {
+	plaintext, err := decryptColdWalletData(fileData, password)
+	if err != nil {
+		return WalletData{}, err
+	}
+
+	// JSON 파싱
+	var walletData WalletData
+	err = json.Unmarshal(plaintext, &walletData)
+	if err != nil {
+		return WalletData{}, fmt.Errorf("지갑 데이터 파싱 실패: %v", err)
+	}
+
+	return walletData, nil
+}
+
+// decryptColdWalletData coldwallet 호환 형식의 파일 내용을 복호화하여 원본 데이터 반환
+func decryptColdWalletData(fileData []byte, password string) ([]byte, error) {
 	// coldwallet 파일 형식 파싱
 	var fileFormat ColdWalletFileFormat
 	err := json.Unmarshal(fileData, &fileFormat)
 	if err != nil {
-		return WalletData{}, fmt.Errorf("잘못된 파일 형식: %v", err)
+		return nil, fmt.Errorf("잘못된 파일 형식: %v", err)
 	}
 
 	// 버전 확인
 	if fileFormat.Version != "2.0" {
-		return WalletData{}, fmt.Errorf("지원되지 않는 지갑 버전: %s", fileFormat.Version)
+		return nil, fmt.Errorf("지원되지 않는 지갑 버전: %s", fileFormat.Version)
 	}
 
 	// Salt 및 암호화된 데이터 디코딩
 	salt, err := hex.DecodeString(fileFormat.Salt)
 	if err != nil {
-		return WalletData{}, fmt.Errorf("Salt 디코딩 실패: %v", err)
+		return nil, fmt.Errorf("Salt 디코딩 실패: %v", err)
 	}
 
 	encrypted, err := hex.DecodeString(fileFormat.Data)
 	if err != nil {
-		return WalletData{}, fmt.Errorf("암호화 데이터 디코딩 실패: %v", err)
+		return nil, fmt.Errorf("암호화 데이터 디코딩 실패: %v", err)
 	}
 
 	// PBKDF2로 키 생성
@@ -679,18 +721,18 @@ func (a *App) decryptColdWallet(fileData []byte, password string) (WalletData, e
 	// createDecipher는 키를 직접 사용
 	block, err := aes.NewCipher(key)
 	if err != nil {
-		return WalletData{}, err
+		return nil, err
 	}
 
 	// 블록 크기 확인
 	if len(encrypted)%aes.BlockSize != 0 {
-		return WalletData{}, fmt.Errorf("잘못된 비밀번호이거나 손상된 파일입니다")
+		return nil, fmt.Errorf("잘못된 비밀번호이거나 손상된 파일입니다")
 	}
 
 	// 파일에 저장된 IV 사용
 	iv, err := hex.DecodeString(fileFormat.IV)
 	if err != nil {
-		return WalletData{}, fmt.Errorf("IV 디코딩 실패: %v", err)
+		return nil, fmt.Errorf("IV 디코딩 실패: %v", err)
 	}
 
 	mode := cipher.NewCBCDecrypter(block, iv)
@@ -701,14 +743,7 @@ func (a *App) decryptColdWallet(fileData []byte, password string) (WalletData, e
 	// PKCS7 패딩 제거
 	plaintext, err = pkcs7Unpad(plaintext)
 	if err != nil {
-		return WalletData{}, fmt.Errorf("잘못된 비밀번호입니다")
-	}
-
-	// JSON 파싱
-	var walletData WalletData
-	err = json.Unmarshal(plaintext, &walletData)
-	if err != nil {
-		return WalletData{}, fmt.Errorf("지갑 데이터 파싱 실패: %v", err)
+		return nil, fmt.Errorf("잘못된 비밀번호입니다")
 	}
 
 	// 체크섬 검증 (선택사항)
@@ -718,13 +753,13 @@ func (a *App) decryptColdWallet(fileData []byte, password string) (WalletData, e
 			actualChecksum := sha256.Sum256(plaintext)
 			for i, b := range actualChecksum {
 				if i >= len(expectedChecksum) || b != expectedChecksum[i] {
-					return WalletData{}, fmt.Errorf("지갑 데이터 무결성 검증 실패")
+					return nil, fmt.Errorf("지갑 데이터 무결성 검증 실패")
 				}
 			}
 		}
 	}
 
-	return walletData, nil
+	return plaintext, nil
 }
 
 // pkcs7Unpad PKCS7 패딩 제거
@@ -817,13 +852,14 @@ type GetBalanceRequest struct {
 
 // GetBalanceResponse 잔액 조회 응답 구조체
 type GetBalanceResponse struct {
-	Success     bool    `json:"success"`     // 성공 여부
-	Message     string  `json:"message"`     // 응답 메시지
-	Balance     float64 `json:"balance"`     // 잔액 (BTC)
-	BalanceSat  int64   `json:"balanceSat"`  // 잔액 (satoshi)
-	Confirmed   float64 `json:"confirmed"`   // 확인된 잔액 (BTC)
-	Unconfirmed float64 `json:"unconfirmed"` // 미확인 잔액 (BTC)
-	UTXOCount   int     `json:"utxoCount"`   // UTXO 개수
+	Success        bool                `json:"success"`        // 성공 여부
+	Message        string              `json:"message"`        // 응답 메시지
+	ErrorCode      string              `json:"errorCode"`      // 에러 코드 (다국어 처리용)
+	BalanceSat     int64               `json:"balanceSat"`     // 잔액 (satoshi)
+	ConfirmedSat   int64               `json:"confirmedSat"`   // 확인된 잔액 (satoshi)
+	UnconfirmedSat int64               `json:"unconfirmedSat"` // 미확인 잔액 (satoshi)
+	UTXOCount      int                 `json:"utxoCount"`      // UTXO 개수
+	Discrepancies  []QuorumDiscrepancy `json:"discrepancies"`  // 교차 검증 불일치 항목 (교차 검증 모드)
 }
 
 // AddressStats 주소 통계 정보 (Blockstream API)
@@ -853,151 +889,139 @@ func (a *App) GetBalance(request GetBalanceRequest) GetBalanceResponse {
 		}
 	}
 
-	// 1. 주소 통계 조회
-	statsUrl := fmt.Sprintf("https://blockstream.info/api/address/%s", request.Address)
-
-	resp, err := http.Get(statsUrl)
-	if err != nil {
-		return GetBalanceResponse{
-			Success: false,
-			Message: fmt.Sprintf("주소 통계 조회 실패: %v", err),
-		}
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return GetBalanceResponse{
-			Success: false,
-			Message: fmt.Sprintf("주소 통계 API 오류: %d", resp.StatusCode),
-		}
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
+	// 1. 주소 통계 조회 (주소 유효성 및 서버 응답 확인)
+	if _, err := a.chain().AddressStats(request.Address); err != nil {
 		return GetBalanceResponse{
-			Success: false,
-			Message: fmt.Sprintf("응답 읽기 실패: %v", err),
-		}
-	}
-
-	var stats AddressStats
-	if err := json.Unmarshal(body, &stats); err != nil {
-		return GetBalanceResponse{
-			Success: false,
-			Message: fmt.Sprintf("주소 통계 파싱 실패: %v", err),
+			Success:   false,
+			Message:   err.Error(),
+			ErrorCode: backendErrorCode(err),
 		}
 	}
 
-	// 2. UTXO 조회하여 확인된 잔액과 미확인 잔액 계산
-	utxos, err := a.fetchUTXOs(request.Address)
+	// 2. 모든 UTXO를 한 번 조회하여 확인된 잔액과 미확인 잔액 계산
+	utxos, err := a.fetchAllUTXOs(request.Address)
 	if err != nil {
 		return GetBalanceResponse{
-			Success: false,
-			Message: fmt.Sprintf("UTXO 조회 실패: %v", err),
+			Success:       false,
+			Message:       fmt.Sprintf("UTXO 조회 실패: %v", err),
+			ErrorCode:     backendErrorCode(err),
+			Discrepancies: quorumDiscrepancies(err),
 		}
 	}
 
-	// 확인된 잔액 계산
 	var confirmedBalance int64
 	var unconfirmedBalance int64
-
-	// 모든 UTXO 조회 (확인된 것과 미확인된 것 모두)
-	allUtxosUrl := fmt.Sprintf("https://blockstream.info/api/address/%s/utxo", request.Address)
-	resp, err = http.Get(allUtxosUrl)
-	if err == nil && resp.StatusCode == 200 {
-		body, err := io.ReadAll(resp.Body)
-		if err == nil {
-			var allUtxos []UTXO
-			if json.Unmarshal(body, &allUtxos) == nil {
-				for _, utxo := range allUtxos {
-					if utxo.Status.Confirmed {
-						confirmedBalance += utxo.Value
-					} else {
-						unconfirmedBalance += utxo.Value
-					}
-				}
-			}
+	confirmedCount := 0
+	for _, utxo := range utxos {
+		if utxo.Status.Confirmed {
+			confirmedBalance += utxo.Value
+			confirmedCount++
+		} else {
+			unconfirmedBalance += utxo.Value
 		}
-		resp.Body.Close()
 	}
 
 	// 총 잔액 계산
 	totalBalance := confirmedBalance + unconfirmedBalance
 
 	return GetBalanceResponse{
-		Success:     true,
-		Message:     "잔액 조회 성공",
-		Balance:     float64(totalBalance) / 100000000,       // BTC 단위
-		BalanceSat:  totalBalance,                            // satoshi 단위
-		Confirmed:   float64(confirmedBalance) / 100000000,   // 확인된 잔액 (BTC)
-		Unconfirmed: float64(unconfirmedBalance) / 100000000, // 미확인 잔액 (BTC)
-		UTXOCount:   len(utxos),                              // 확인된 UTXO 개수
+		Success:        true,
+		Message:        "잔액 조회 성공",
+		BalanceSat:     totalBalance,       // 잔액 (satoshi)
+		ConfirmedSat:   confirmedBalance,   // 확인된 잔액 (satoshi)
+		UnconfirmedSat: unconfirmedBalance, // 미확인 잔액 (satoshi)
+		UTXOCount:      confirmedCount,     // 확인된 UTXO 개수
 	}
 }
 
 // SendBitcoinRequest 비트코인 전송 요청 구조체
 type SendBitcoinRequest struct {
-	WalletData                WalletData `json:"walletData"`                // 지갑 데이터
-	RecipientAddress          string     `json:"recipientAddress"`          // 받는 주소
-	Amount                    float64    `json:"amount"`                    // 전송 금액 (BTC)
-	FeeSatoshi                int        `json:"feeSatoshi"`                // 수수료 (사토시)
-	IsDeveloperFeeTransaction bool       `json:"isDeveloperFeeTransaction"` // 개발자 수수료 트랜잭션 여부
-	EnableFeeSplit            bool       `json:"enableFeeSplit"`            // 수수료 분할 활성화 여부
-	DeveloperAddress          string     `json:"developerAddress"`          // 개발자 비트코인 주소
-	DeveloperFeeSatoshi       int        `json:"developerFeeSatoshi"`       // 개발자 수수료 (사토시)
+	WalletData                WalletData       `json:"walletData"`                // 지갑 데이터
+	RecipientAddress          string           `json:"recipientAddress"`          // 받는 주소
+	AmountSat                 int64            `json:"amountSat"`                 // 전송 금액 (satoshi)
+	FeeSatoshi                int              `json:"feeSatoshi"`                // 수수료 (사토시)
+	IsDeveloperFeeTransaction bool             `json:"isDeveloperFeeTransaction"` // 개발자 수수료 트랜잭션 여부
+	EnableFeeSplit            bool             `json:"enableFeeSplit"`            // 수수료 분할 활성화 여부
+	DeveloperAddress          string           `json:"developerAddress"`          // 개발자 비트코인 주소
+	DeveloperFeeSatoshi       int              `json:"developerFeeSatoshi"`       // 개발자 수수료 (사토시)
+	SendMax                   bool             `json:"sendMax"`                   // 전체 잔액 전송 여부 (거스름돈 없음)
+	SelectedUTXOs             []string         `json:"selectedUtxos"`             // 전체 전송 시 사용할 UTXO 목록 ("txid:vout", 비어있으면 전체)
+	FeeRate                   float64          `json:"feeRate"`                   // 전체 전송 수수료율 (sat/vB, 지정 시 선택된 입력 수에 맞춰 채굴자 수수료 계산)
+	Recipients                []BatchRecipient `json:"recipients"`                // 일괄 전송 수신자 목록 (지정 시 RecipientAddress/AmountSat 무시)
+	DisableRBF                bool             `json:"disableRbf"`                // RBF(BIP125) 신호 비활성화 여부 (기본: 활성화)
+	PaymentURI                string           `json:"paymentUri"`                // BIP21 결제 URI (지정 시 주소와 금액을 URI에서 가져옴)
+	ConfirmationToken         string           `json:"confirmationToken"`         // PrepareTransaction에서 발급한 확인 토큰 (전송 시 필수)
+	ExportOnly                bool             `json:"exportOnly"`                // 브로드캐스트 없이 서명된 거래만 내보내기
+	ExportDir                 string           `json:"exportDir"`                 // 내보낸 .txn 파일 저장 경로 (비어있으면 기본 경로)
+	WalletFilePath            string           `json:"walletFilePath"`            // 지갑 파일 경로 (주소록에 없는 받는 주소 경고용, 선택)
+	WalletPassword            string           `json:"walletPassword"`            // 지갑 비밀번호 (주소록 복호화용)
 }
 
 // SendBitcoinResponse 비트코인 전송 응답 구조체
 type SendBitcoinResponse struct {
-	Success   bool   `json:"success"`   // 성공 여부
-	Message   string `json:"message"`   // 응답 메시지
-	ErrorCode string `json:"errorCode"` // 에러 코드 (다국어 처리용)
-	TxHash    string `json:"txHash"`    // 거래 해시
+	Success       bool                `json:"success"`       // 성공 여부
+	Message       string              `json:"message"`       // 응답 메시지
+	ErrorCode     string              `json:"errorCode"`     // 에러 코드 (다국어 처리용)
+	TxHash        string              `json:"txHash"`        // 거래 해시
+	AmountSat     int64               `json:"amountSat"`     // 실제 전송된 금액 (satoshi)
+	TxID          string              `json:"txid"`          // 로컬에서 계산한 거래 ID
+	WTxID         string              `json:"wtxid"`         // 로컬에서 계산한 witness 거래 ID
+	RawTx         string              `json:"rawTx"`         // 서명된 거래 (hex, 내보내기 시)
+	FilePath      string              `json:"filePath"`      // 내보낸 .txn 파일 경로
+	Retryable     bool                `json:"retryable"`     // 같은 확인 토큰으로 다시 전송 가능 여부 (일시적 네트워크 오류)
+	Discrepancies []QuorumDiscrepancy `json:"discrepancies"` // 교차 검증 불일치 항목 (교차 검증 모드)
+}
+
+// TxStatus 거래 확인 상태 (Blockstream API)
+type TxStatus struct {
+	Confirmed   bool   `json:"confirmed"`
+	BlockHeight int64  `json:"block_height"`
+	BlockHash   string `json:"block_hash"`
+	BlockTime   int64  `json:"block_time"`
 }
 
 // UTXO 비트코인 UTXO 정보 구조체
 type UTXO struct {
-	TxID   string `json:"txid"`
-	Vout   int    `json:"vout"`
-	Value  int64  `json:"value"`
-	Status struct {
-		Confirmed bool `json:"confirmed"`
-	} `json:"status"`
+	TxID   string   `json:"txid"`
+	Vout   int      `json:"vout"`
+	Value  int64    `json:"value"`
+	Status TxStatus `json:"status"`
 }
 
 // TxOutput 거래 출력 정보
 type TxOutput struct {
-	ScriptPubKey string `json:"scriptpubkey"`
-	Value        int64  `json:"value"`
+	ScriptPubKey        string `json:"scriptpubkey"`
+	ScriptPubKeyAddress string `json:"scriptpubkey_address"`
+	Value               int64  `json:"value"`
+}
+
+// TxInput 거래 입력 정보
+type TxInput struct {
+	TxID     string    `json:"txid"`
+	Vout     uint32    `json:"vout"`
+	Prevout  *TxOutput `json:"prevout"`
+	Sequence uint32    `json:"sequence"`
+	Witness  []string  `json:"witness"`
 }
 
 // TxDetails 거래 세부정보
 type TxDetails struct {
-	Vout []TxOutput `json:"vout"`
+	TxID     string     `json:"txid"`
+	Version  int32      `json:"version"`
+	Locktime uint32     `json:"locktime"`
+	Vin      []TxInput  `json:"vin"`
+	Vout     []TxOutput `json:"vout"`
+	Size     int64      `json:"size"`
+	Weight   int64      `json:"weight"`
+	Fee      int64      `json:"fee"`
+	Status   TxStatus   `json:"status"`
 }
 
-// fetchUTXOs 주소의 UTXO 조회 (Blockstream API 사용)
+// fetchUTXOs 주소의 확인된 UTXO 조회
 func (a *App) fetchUTXOs(address string) ([]UTXO, error) {
-	url := fmt.Sprintf("https://blockstream.info/api/address/%s/utxo", address)
-
-	resp, err := http.Get(url)
+	utxos, err := a.fetchAllUTXOs(address)
 	if err != nil {
-		return nil, fmt.Errorf("UTXO 조회 실패: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("UTXO 조회 API 오류: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("응답 읽기 실패: %v", err)
-	}
-
-	var utxos []UTXO
-	if err := json.Unmarshal(body, &utxos); err != nil {
-		return nil, fmt.Errorf("UTXO 파싱 실패: %v", err)
+		return nil, err
 	}
 
 	// 확인된 UTXO만 필터링
@@ -1011,62 +1035,29 @@ func (a *App) fetchUTXOs(address string) ([]UTXO, error) {
 	return confirmedUTXOs, nil
 }
 
+// fetchAllUTXOs 주소의 모든 UTXO 조회 (미확인 UTXO 포함)
+func (a *App) fetchAllUTXOs(address string) ([]UTXO, error) {
+	return a.chain().AddressUTXOs(address)
+}
+
 // fetchTxDetails 거래 세부정보 조회
 func (a *App) fetchTxDetails(txid string) (*TxDetails, error) {
-	url := fmt.Sprintf("https://blockstream.info/api/tx/%s", txid)
-
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("거래 세부정보 조회 실패: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("거래 세부정보 API 오류: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("응답 읽기 실패: %v", err)
-	}
-
-	var txDetails TxDetails
-	if err := json.Unmarshal(body, &txDetails); err != nil {
-		return nil, fmt.Errorf("거래 세부정보 파싱 실패: %v", err)
-	}
-
-	return &txDetails, nil
+	return a.chain().Transaction(txid)
 }
 
 // broadcastTransaction 거래 브로드캐스트
 func (a *App) broadcastTransaction(txHex string) (string, error) {
-	url := "https://blockstream.info/api/tx"
-
-	resp, err := http.Post(url, "text/plain", strings.NewReader(txHex))
-	if err != nil {
-		return "", fmt.Errorf("거래 브로드캐스트 실패: %v", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("응답 읽기 실패: %v", err)
-	}
-
-	if resp.StatusCode != 200 {
-		return "", fmt.Errorf("브로드캐스트 오류: %s", string(body))
-	}
-
-	return string(body), nil
+	return a.chain().Broadcast(txHex)
 }
 
-// SendBitcoinTransaction 실제 비트코인 거래 전송
-func (a *App) SendBitcoinTransaction(request SendBitcoinRequest) SendBitcoinResponse {
+// buildTransactionPlan 전송 요청을 검증하고 UTXO 선택 및 출력 구성까지 마친 서명 전 거래 계획 생성
+// 실패 시 nil과 함께 오류 응답 반환
+func (a *App) buildTransactionPlan(request SendBitcoinRequest) (*pendingTransaction, SendBitcoinResponse) {
 	// 요청 데이터 로깅
 	/*
 		fmt.Printf("=== SendBitcoinTransaction 호출 ===\n")
 		fmt.Printf("받는 주소: %s\n", request.RecipientAddress)
-		fmt.Printf("전송 금액: %.8f BTC\n", request.Amount)
+		fmt.Printf("전송 금액: %d satoshi\n", request.AmountSat)
 		fmt.Printf("수수료: %d satoshi\n", request.FeeSatoshi)
 		fmt.Printf("수수료 분할 활성화: %t\n", request.EnableFeeSplit)
 		if request.EnableFeeSplit {
@@ -1077,51 +1068,113 @@ func (a *App) SendBitcoinTransaction(request SendBitcoinRequest) SendBitcoinResp
 		fmt.Printf("=====================================\n")
 	*/
 
-	// 입력 값 검증
-	if request.RecipientAddress == "" {
-		return SendBitcoinResponse{
-			Success: false,
-			Message: "받는 주소를 입력해주세요",
+	// BIP21 결제 URI가 있으면 받는 주소와 금액을 URI에서 가져옴
+	if request.PaymentURI != "" {
+		payment, errorCode, err := parsePaymentURI(request.PaymentURI)
+		if err != nil {
+			return nil, SendBitcoinResponse{
+				Success:   false,
+				Message:   err.Error(),
+				ErrorCode: errorCode,
+			}
+		}
+
+		request.RecipientAddress = payment.Address
+		if payment.AmountSat > 0 {
+			if request.AmountSat != 0 && request.AmountSat != payment.AmountSat {
+				return nil, SendBitcoinResponse{
+					Success:   false,
+					Message:   "입력한 금액이 결제 URI의 요청 금액과 다릅니다",
+					ErrorCode: "URI_AMOUNT_MISMATCH",
+				}
+			}
+			request.AmountSat = payment.AmountSat
 		}
 	}
 
-	if request.Amount <= 0 {
-		return SendBitcoinResponse{
-			Success: false,
-			Message: "전송 금액은 0보다 커야 합니다",
+	// 입력 값 검증
+	isBatch := len(request.Recipients) > 0
+	var batchOutputs []paymentOutput
+	if isBatch {
+		// 일괄 전송: 수신자별 주소/중복/더스트 검증
+		if request.SendMax {
+			return nil, SendBitcoinResponse{
+				Success:   false,
+				Message:   "일괄 전송에서는 전체 잔액 전송을 사용할 수 없습니다",
+				ErrorCode: "BATCH_SEND_MAX_UNSUPPORTED",
+			}
+		}
+
+		outputs, errorCode, err := validateBatchRecipients(request.Recipients)
+		if err != nil {
+			return nil, SendBitcoinResponse{
+				Success:   false,
+				Message:   err.Error(),
+				ErrorCode: errorCode,
+			}
+		}
+		batchOutputs = outputs
+	} else {
+		if request.RecipientAddress == "" {
+			return nil, SendBitcoinResponse{
+				Success: false,
+				Message: "받는 주소를 입력해주세요",
+			}
+		}
+
+		if !request.SendMax && request.AmountSat <= 0 {
+			return nil, SendBitcoinResponse{
+				Success: false,
+				Message: "전송 금액은 0보다 커야 합니다",
+			}
 		}
 	}
 
 	// 전체 수수료 범위 검증 (최소/최대) - 항상 체크
 	if int64(request.FeeSatoshi) < 2000 {
-		return SendBitcoinResponse{
+		return nil, SendBitcoinResponse{
 			Success:   false,
 			Message:   "전체 수수료가 너무 낮습니다. 최소 2000 사토시가 필요합니다.",
 			ErrorCode: "FEE_TOO_LOW",
 		}
 	}
 	if int64(request.FeeSatoshi) > 50000 {
-		return SendBitcoinResponse{
+		return nil, SendBitcoinResponse{
 			Success:   false,
 			Message:   "전체 수수료가 너무 높습니다. 최대 50000 사토시를 초과할 수 없습니다.",
 			ErrorCode: "FEE_TOO_HIGH",
 		}
 	}
 
+	if request.FeeRate < 0 {
+		return nil, SendBitcoinResponse{
+			Success:   false,
+			Message:   "수수료율은 0보다 커야 합니다",
+			ErrorCode: "FEE_RATE_INVALID",
+		}
+	}
+	if request.FeeRate > maxBumpFeeRate {
+		return nil, SendBitcoinResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("수수료율이 너무 높습니다. 최대 %.0f sat/vB를 초과할 수 없습니다.", maxBumpFeeRate),
+			ErrorCode: "FEE_RATE_TOO_HIGH",
+		}
+	}
+
 	// UTXO 조회 전 수수료 분할 시스템 검증
 	if request.EnableFeeSplit {
 
 		// 채굴자 수수료 범위 검증 (최소/최대)
 		minerFeeCheck := int64(request.FeeSatoshi) - int64(request.DeveloperFeeSatoshi)
 		if minerFeeCheck < 1000 {
-			return SendBitcoinResponse{
+			return nil, SendBitcoinResponse{
 				Success:   false,
 				Message:   "채굴자 수수료가 너무 낮습니다. 최소 1000 사토시가 필요합니다.",
 				ErrorCode: "MINER_FEE_TOO_LOW",
 			}
 		}
 		if minerFeeCheck > 50000 {
-			return SendBitcoinResponse{
+			return nil, SendBitcoinResponse{
 				Success:   false,
 				Message:   "채굴자 수수료가 너무 높습니다. 최대 50000 사토시를 초과할 수 없습니다.",
 				ErrorCode: "MINER_FEE_TOO_HIGH",
@@ -1130,14 +1183,14 @@ func (a *App) SendBitcoinTransaction(request SendBitcoinRequest) SendBitcoinResp
 
 		// 개발자 수수료 범위 검증 (최소/최대)
 		if request.DeveloperFeeSatoshi <= 0 {
-			return SendBitcoinResponse{
+			return nil, SendBitcoinResponse{
 				Success:   false,
 				Message:   "개발자 수수료는 0보다 커야 합니다.",
 				ErrorCode: "DEVELOPER_FEE_INVALID",
 			}
 		}
 		if request.DeveloperFeeSatoshi > 10000 {
-			return SendBitcoinResponse{
+			return nil, SendBitcoinResponse{
 				Success:   false,
 				Message:   "개발자 수수료가 너무 높습니다. 최대 10000 사토시를 초과할 수 없습니다.",
 				ErrorCode: "DEVELOPER_FEE_TOO_HIGH",
@@ -1146,7 +1199,7 @@ func (a *App) SendBitcoinTransaction(request SendBitcoinRequest) SendBitcoinResp
 
 		// 개발자 주소 검증
 		if request.DeveloperAddress == "" {
-			return SendBitcoinResponse{
+			return nil, SendBitcoinResponse{
 				Success:   false,
 				Message:   "개발자 주소를 입력해주세요.",
 				ErrorCode: "DEVELOPER_ADDRESS_EMPTY",
@@ -1154,10 +1207,9 @@ func (a *App) SendBitcoinTransaction(request SendBitcoinRequest) SendBitcoinResp
 		}
 	}
 
-	// 더스트 한도 검증 (546 사토시)
-	amountSatoshiCheck := int64(request.Amount * 100000000)
-	if amountSatoshiCheck < 546 {
-		return SendBitcoinResponse{
+	// 더스트 한도 검증 (546 사토시) - 전체 전송은 UTXO 선택 후 검증
+	if !request.SendMax && !isBatch && request.AmountSat < 546 {
+		return nil, SendBitcoinResponse{
 			Success:   false,
 			Message:   "전송 금액이 너무 작습니다. 최소 546 사토시 (0.00000546 BTC)가 필요합니다.",
 			ErrorCode: "AMOUNT_TOO_SMALL",
@@ -1167,21 +1219,29 @@ func (a *App) SendBitcoinTransaction(request SendBitcoinRequest) SendBitcoinResp
 	// 1. UTXO 조회
 	utxos, err := a.fetchUTXOs(request.WalletData.Address)
 	if err != nil {
-		return SendBitcoinResponse{
-			Success: false,
-			Message: fmt.Sprintf("UTXO 조회 실패: %v", err),
+		return nil, SendBitcoinResponse{
+			Success:       false,
+			Message:       fmt.Sprintf("UTXO 조회 실패: %v", err),
+			ErrorCode:     backendErrorCode(err),
+			Discrepancies: quorumDiscrepancies(err),
 		}
 	}
 
 	if len(utxos) == 0 {
-		return SendBitcoinResponse{
+		return nil, SendBitcoinResponse{
 			Success: false,
 			Message: "사용 가능한 UTXO가 없습니다",
 		}
 	}
 
-	// 2. 금액 계산 (BTC to satoshi)
-	amountSatoshi := int64(request.Amount * 100000000)
+	// 2. 금액 계산 (일괄 전송은 수신자 금액 합계)
+	amountSatoshi := request.AmountSat
+	if isBatch {
+		amountSatoshi = 0
+		for _, output := range batchOutputs {
+			amountSatoshi += output.AmountSat
+		}
+	}
 
 	// 3. 입력 선택 및 총 입력 금액 계산
 	var totalInput int64
@@ -1209,18 +1269,44 @@ func (a *App) SendBitcoinTransaction(request SendBitcoinRequest) SendBitcoinResp
 		fmt.Printf("수수료 분할: 전체수수료 %d = 채굴자수수료 %d + 개발자수수료 %d\n", totalFee, minerFee, developerFeeNeeded)
 	}
 
-	for _, utxo := range utxos {
-		selectedUTXOs = append(selectedUTXOs, utxo)
-		totalInput += utxo.Value
+	if request.SendMax {
+		// 전체 전송: 선택된 UTXO (또는 전체 UTXO)를 모두 사용하고 금액은 입력 합계에서 수수료를 차감
+		selectedUTXOs, err = selectSendMaxUTXOs(utxos, request.SelectedUTXOs)
+		if err != nil {
+			return nil, SendBitcoinResponse{
+				Success:   false,
+				Message:   err.Error(),
+				ErrorCode: "UTXO_NOT_FOUND",
+			}
+		}
+		for _, utxo := range selectedUTXOs {
+			totalInput += utxo.Value
+		}
 
-		if totalInput >= totalNeeded {
-			break
+		amountSatoshi = totalInput - totalFee
+		if amountSatoshi < 546 {
+			return nil, SendBitcoinResponse{
+				Success:   false,
+				Message:   fmt.Sprintf("수수료를 제외한 전송 금액이 너무 작습니다. 보유: %d satoshi, 수수료: %d satoshi", totalInput, totalFee),
+				ErrorCode: "AMOUNT_TOO_SMALL",
+			}
+		}
+		totalNeeded = amountSatoshi + totalFee
+		fmt.Printf("전체 전송: 입력 %d satoshi → 전송 %d satoshi (거스름돈 없음)\n", totalInput, amountSatoshi)
+	} else {
+		for _, utxo := range utxos {
+			selectedUTXOs = append(selectedUTXOs, utxo)
+			totalInput += utxo.Value
+
+			if totalInput >= totalNeeded {
+				break
+			}
 		}
 	}
 
 	// 잔액 확인
 	if totalInput < totalNeeded {
-		return SendBitcoinResponse{
+		return nil, SendBitcoinResponse{
 			Success: false,
 			Message: fmt.Sprintf("잔액이 부족합니다. 필요: %d satoshi, 보유: %d satoshi", totalNeeded, totalInput),
 		}
@@ -1233,7 +1319,7 @@ func (a *App) SendBitcoinTransaction(request SendBitcoinRequest) SendBitcoinResp
 	for _, utxo := range selectedUTXOs {
 		txHash, err := hex.DecodeString(utxo.TxID)
 		if err != nil {
-			return SendBitcoinResponse{
+			return nil, SendBitcoinResponse{
 				Success: false,
 				Message: fmt.Sprintf("거래 해시 디코딩 실패: %v", err),
 			}
@@ -1246,44 +1332,67 @@ func (a *App) SendBitcoinTransaction(request SendBitcoinRequest) SendBitcoinResp
 
 		hash, err := chainhash.NewHash(txHash)
 		if err != nil {
-			return SendBitcoinResponse{
+			return nil, SendBitcoinResponse{
 				Success: false,
 				Message: fmt.Sprintf("거래 해시 생성 실패: %v", err),
 			}
 		}
 		outPoint := wire.NewOutPoint(hash, uint32(utxo.Vout))
 		txIn := wire.NewTxIn(outPoint, nil, nil)
+		if !request.DisableRBF {
+			// BIP125 RBF 신호 (수수료 올리기 가능)
+			txIn.Sequence = rbfSequence
+		}
 		tx.AddTxIn(txIn)
 	}
 
-	// 받는 주소 파싱
-	recipientAddr, err := btcutil.DecodeAddress(request.RecipientAddress, &chaincfg.MainNetParams)
-	if err != nil {
-		return SendBitcoinResponse{
-			Success: false,
-			Message: fmt.Sprintf("받는 주소 형식 오류: %v", err),
+	var planOutputs []TransactionPlanOutput
+	if isBatch {
+		// 일괄 전송: 수신자별 출력 추가 (거스름돈 출력은 아래에서 하나만 추가)
+		for _, output := range batchOutputs {
+			tx.AddTxOut(wire.NewTxOut(output.AmountSat, output.Script))
+			planOutputs = append(planOutputs, TransactionPlanOutput{
+				Address:   output.Address,
+				AmountSat: output.AmountSat,
+				Type:      "recipient",
+				Label:     output.Label,
+			})
+		}
+	} else {
+		// 받는 주소 파싱
+		recipientAddr, err := btcutil.DecodeAddress(request.RecipientAddress, &chaincfg.MainNetParams)
+		if err != nil {
+			return nil, SendBitcoinResponse{
+				Success: false,
+				Message: fmt.Sprintf("받는 주소 형식 오류: %v", err),
+			}
 		}
-	}
 
-	// 받는 주소 출력 스크립트 생성
-	recipientScript, err := txscript.PayToAddrScript(recipientAddr)
-	if err != nil {
-		return SendBitcoinResponse{
-			Success: false,
-			Message: fmt.Sprintf("받는 주소 스크립트 생성 실패: %v", err),
+		// 받는 주소 출력 스크립트 생성
+		recipientScript, err := txscript.PayToAddrScript(recipientAddr)
+		if err != nil {
+			return nil, SendBitcoinResponse{
+				Success: false,
+				Message: fmt.Sprintf("받는 주소 스크립트 생성 실패: %v", err),
+			}
 		}
-	}
 
-	// 받는 주소 출력 추가
-	txOut := wire.NewTxOut(amountSatoshi, recipientScript)
-	tx.AddTxOut(txOut)
+		// 받는 주소 출력 추가
+		txOut := wire.NewTxOut(amountSatoshi, recipientScript)
+		tx.AddTxOut(txOut)
+		planOutputs = append(planOutputs, TransactionPlanOutput{
+			Address:   request.RecipientAddress,
+			AmountSat: amountSatoshi,
+			Type:      "recipient",
+		})
+	}
 
 	// 개발자 수수료 출력 추가 (수수료 분할이 활성화된 경우)
 	var developerFeeSatoshi int64 = 0
 	if request.EnableFeeSplit && request.DeveloperAddress != "" {
 		developerAddr, err := btcutil.DecodeAddress(request.DeveloperAddress, &chaincfg.MainNetParams)
 		if err != nil {
-			return SendBitcoinResponse{
+			return nil, SendBitcoinResponse{
 				Success: false,
 				Message: fmt.Sprintf("개발자 주소 형식 오류: %v", err),
 			}
@@ -1291,7 +1400,7 @@ func (a *App) SendBitcoinTransaction(request SendBitcoinRequest) SendBitcoinResp
 
 		developerScript, err := txscript.PayToAddrScript(developerAddr)
 		if err != nil {
-			return SendBitcoinResponse{
+			return nil, SendBitcoinResponse{
 				Success: false,
 				Message: fmt.Sprintf("개발자 주소 스크립트 생성 실패: %v", err),
 			}
@@ -1300,10 +1409,32 @@ func (a *App) SendBitcoinTransaction(request SendBitcoinRequest) SendBitcoinResp
 		developerFeeSatoshi = int64(request.DeveloperFeeSatoshi)
 		developerTxOut := wire.NewTxOut(developerFeeSatoshi, developerScript)
 		tx.AddTxOut(developerTxOut)
+		planOutputs = append(planOutputs, TransactionPlanOutput{
+			Address:   request.DeveloperAddress,
+			AmountSat: developerFeeSatoshi,
+			Type:      "developer_fee",
+		})
 
 		fmt.Printf("개발자 수수료 출력 추가: %d satoshi → %s\n", developerFeeSatoshi, request.DeveloperAddress)
 	}
 
+	// 전체 전송에 수수료율이 지정되면 선택된 입력 수에 맞는 크기로 채굴자 수수료를 다시 계산
+	// (프론트엔드의 고정 수수료는 입력 1개 기준이라 입력이 많으면 수수료율이 크게 낮아짐)
+	if request.SendMax && request.FeeRate > 0 {
+		minerFee = int64(math.Ceil(request.FeeRate * float64(estimateVSize(tx))))
+		amountSatoshi = totalInput - developerFeeSatoshi - minerFee
+		if amountSatoshi < 546 {
+			return nil, SendBitcoinResponse{
+				Success:   false,
+				Message:   fmt.Sprintf("수수료를 제외한 전송 금액이 너무 작습니다. 보유: %d satoshi, 수수료: %d satoshi", totalInput, developerFeeSatoshi+minerFee),
+				ErrorCode: "AMOUNT_TOO_SMALL",
+			}
+		}
+		tx.TxOut[0].Value = amountSatoshi
+		planOutputs[0].AmountSat = amountSatoshi
+		fmt.Printf("전체 전송 수수료 재계산: %.1f sat/vB × %d vB = %d satoshi\n", request.FeeRate, estimateVSize(tx), minerFee)
+	}
+
 	// 실제 채굴자 수수료 사용 (전체 수수료에서 개발자 수수료 차감한 값)
 	actualMinerFee := minerFee
 
@@ -1314,7 +1445,7 @@ func (a *App) SendBitcoinTransaction(request SendBitcoinRequest) SendBitcoinResp
 	if change >= 546 {
 		changeAddr, err := btcutil.DecodeAddress(request.WalletData.Address, &chaincfg.MainNetParams)
 		if err != nil {
-			return SendBitcoinResponse{
+			return nil, SendBitcoinResponse{
 				Success: false,
 				Message: fmt.Sprintf("거스름돈 주소 파싱 실패: %v", err),
 			}
@@ -1322,7 +1453,7 @@ func (a *App) SendBitcoinTransaction(request SendBitcoinRequest) SendBitcoinResp
 
 		changeScript, err := txscript.PayToAddrScript(changeAddr)
 		if err != nil {
-			return SendBitcoinResponse{
+			return nil, SendBitcoinResponse{
 				Success: false,
 				Message: fmt.Sprintf("거스름돈 스크립트 생성 실패: %v", err),
 			}
@@ -1330,9 +1461,74 @@ func (a *App) SendBitcoinTransaction(request SendBitcoinRequest) SendBitcoinResp
 
 		changeTxOut := wire.NewTxOut(change, changeScript)
 		tx.AddTxOut(changeTxOut)
+		planOutputs = append(planOutputs, TransactionPlanOutput{
+			Address:   request.WalletData.Address,
+			AmountSat: change,
+			Type:      "change",
+			IsChange:  true,
+		})
 	}
 
-	// 5. 거래 서명
+	// 5. 서명에 필요한 이전 출력 정보 수집
+	// 모든 입력은 지갑 주소의 UTXO이므로 스크립트는 지갑 주소에서 바로 생성 (네트워크 조회 불필요)
+	walletAddr, err := btcutil.DecodeAddress(request.WalletData.Address, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, SendBitcoinResponse{
+			Success: false,
+			Message: fmt.Sprintf("지갑 주소 파싱 실패: %v", err),
+		}
+	}
+	walletScript, err := txscript.PayToAddrScript(walletAddr)
+	if err != nil {
+		return nil, SendBitcoinResponse{
+			Success: false,
+			Message: fmt.Sprintf("지갑 스크립트 생성 실패: %v", err),
+		}
+	}
+
+	prevOutScripts := make([][]byte, len(selectedUTXOs))
+	prevOutValues := make([]int64, len(selectedUTXOs))
+	for i, utxo := range selectedUTXOs {
+		prevOutScripts[i] = walletScript
+		prevOutValues[i] = utxo.Value
+	}
+
+	// 실제 채굴자 수수료 = 입력 합계 - 출력 합계 (더스트 거스름돈은 수수료에 포함됨)
+	var totalOutput int64
+	for _, txOut := range tx.TxOut {
+		totalOutput += txOut.Value
+	}
+
+	return &pendingTransaction{
+		tx:              tx,
+		walletAddress:   request.WalletData.Address,
+		inputs:          selectedUTXOs,
+		outputs:         planOutputs,
+		prevOutScripts:  prevOutScripts,
+		prevOutValues:   prevOutValues,
+		totalInputSat:   totalInput,
+		amountSat:       amountSatoshi,
+		developerFeeSat: developerFeeSatoshi,
+		minerFeeSat:     totalInput - totalOutput,
+		absorbedSat:     totalInput - totalOutput - actualMinerFee,
+		rbf:             !request.DisableRBF,
+	}, SendBitcoinResponse{}
+}
+
+// SendBitcoinTransaction 사용자가 확인한 거래 계획을 서명하여 전송
+// PrepareTransaction에서 발급한 일회용 확인 토큰이 필요하며, 확인된 거래 그대로 서명함
+func (a *App) SendBitcoinTransaction(request SendBitcoinRequest) SendBitcoinResponse {
+	// 1. 확인 토큰으로 거래 계획 조회 (한 번 사용하면 폐기)
+	plan, err := a.takePendingTransaction(request.ConfirmationToken, request.WalletData.Address)
+	if err != nil {
+		return SendBitcoinResponse{
+			Success:   false,
+			Message:   err.Error(),
+			ErrorCode: "CONFIRMATION_INVALID",
+		}
+	}
+
+	// 2. 거래 서명
 	privKeyWIF, err := btcutil.DecodeWIF(request.WalletData.PrivateKeyWIF)
 	if err != nil {
 		return SendBitcoinResponse{
@@ -1341,41 +1537,127 @@ func (a *App) SendBitcoinTransaction(request SendBitcoinRequest) SendBitcoinResp
 		}
 	}
 
-	// 각 입력에 대해 서명
-	for i, utxo := range selectedUTXOs {
-		// 거래 세부정보 조회하여 스크립트 가져오기
-		txDetails, err := a.fetchTxDetails(utxo.TxID)
-		if err != nil {
-			return SendBitcoinResponse{
-				Success: false,
-				Message: fmt.Sprintf("거래 세부정보 조회 실패: %v", err),
-			}
+	// 서명 전에 이전 거래 원본으로 입력 금액과 스크립트 검증 (서버 데이터 위조 방지)
+	tx := plan.tx
+	fetcher, err := a.verifyPrevOuts(tx, plan.prevOutScripts, plan.prevOutValues)
+	if err != nil {
+		return SendBitcoinResponse{
+			Success:       false,
+			Message:       err.Error(),
+			ErrorCode:     prevOutErrorCode(err),
+			Discrepancies: quorumDiscrepancies(err),
 		}
+	}
 
-		if utxo.Vout >= len(txDetails.Vout) {
-			return SendBitcoinResponse{
-				Success: false,
-				Message: "잘못된 UTXO 인덱스",
-			}
+	if err := signP2WPKHInputs(tx, fetcher, privKeyWIF); err != nil {
+		return SendBitcoinResponse{
+			Success: false,
+			Message: err.Error(),
 		}
+	}
+
+	// 3. 거래 직렬화
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return SendBitcoinResponse{
+			Success: false,
+			Message: fmt.Sprintf("거래 직렬화 실패: %v", err),
+		}
+	}
+
+	txHex := hex.EncodeToString(buf.Bytes())
+	txid := tx.TxHash().String()
+	wtxid := tx.WitnessHash().String()
 
-		prevOutScript, err := hex.DecodeString(txDetails.Vout[utxo.Vout].ScriptPubKey)
+	// 내보내기 모드: 브로드캐스트 없이 서명된 거래를 .txn 파일로 저장
+	if request.ExportOnly {
+		filePath, err := saveRawTransaction(txHex, txid, request.ExportDir)
 		if err != nil {
 			return SendBitcoinResponse{
 				Success: false,
-				Message: fmt.Sprintf("이전 출력 스크립트 디코딩 실패: %v", err),
+				Message: fmt.Sprintf("거래 파일 저장 실패: %v", err),
 			}
 		}
 
-		// P2WPKH 서명 해시 계산 (간단한 fetcher 생성)
-		prevOutputFetcher := txscript.NewCannedPrevOutputFetcher(prevOutScript, utxo.Value)
-		sigHashes := txscript.NewTxSigHashes(tx, prevOutputFetcher)
-		sigHash, err := txscript.CalcWitnessSigHash(prevOutScript, sigHashes, txscript.SigHashAll, tx, i, utxo.Value)
+		return SendBitcoinResponse{
+			Success:   true,
+			Message:   "서명된 거래를 내보냈습니다 (브로드캐스트되지 않음)",
+			TxHash:    txid,
+			AmountSat: plan.amountSat,
+			TxID:      txid,
+			WTxID:     wtxid,
+			RawTx:     txHex,
+			FilePath:  filePath,
+		}
+	}
+
+	// 4. 거래 브로드캐스트
+	txHash, err := a.broadcastTransaction(txHex)
+	if err != nil {
+		// 일시적 네트워크 오류이면 같은 토큰으로 다시 시도할 수 있도록 계획을 되돌려 놓음
+		// (같은 거래를 다시 서명하므로 이미 전파된 경우에도 이중 지불이 되지 않음)
+		code := backendErrorCode(err)
+		retryable := isRetryableCode(code) && a.restorePendingTransaction(request.ConfirmationToken, plan)
+		return SendBitcoinResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("거래 브로드캐스트 실패: %v", err),
+			ErrorCode: code,
+			TxID:      txid,
+			Retryable: retryable,
+		}
+	}
+
+	changeIndex := -1
+	for i, output := range plan.outputs {
+		if output.IsChange {
+			changeIndex = i
+		}
+	}
+	a.rememberChange(txid, changeIndex)
+
+	return SendBitcoinResponse{
+		Success:   true,
+		Message:   "거래가 성공적으로 전송되었습니다",
+		TxHash:    txHash,
+		AmountSat: plan.amountSat,
+		TxID:      txid,
+		WTxID:     wtxid,
+	}
+}
+
+// saveRawTransaction 서명된 거래 hex를 <txid>.txn 파일로 저장
+func saveRawTransaction(txHex, txid, saveDir string) (string, error) {
+	if saveDir == "" {
+		saveDir = defaultSaveDirectory()
+	}
+
+	// 파일이 이미 존재하면 번호를 추가하여 중복 방지
+	filePath := uniqueFilePath(saveDir, txid, ".txn")
+
+	if err := os.WriteFile(filePath, []byte(txHex+"\n"), 0600); err != nil {
+		return "", err
+	}
+
+	return filePath, nil
+}
+
+// signP2WPKHInputs 모든 입력을 지갑 개인키로 P2WPKH 서명하고 witness 설정
+// 서명 해시는 검증된 모든 이전 출력을 담은 fetcher로 계산
+func signP2WPKHInputs(tx *wire.MsgTx, fetcher *txscript.MultiPrevOutFetcher, privKeyWIF *btcutil.WIF) error {
+	// 공개키
+	pubKey := privKeyWIF.PrivKey.PubKey().SerializeCompressed()
+	sigHashes := txscript.NewTxSigHashes(tx, fetcher)
+
+	for i, txIn := range tx.TxIn {
+		prevOut := fetcher.FetchPrevOutput(txIn.PreviousOutPoint)
+		if prevOut == nil {
+			return fmt.Errorf("입력 %d의 이전 출력 정보가 없습니다", i)
+		}
+
+		// P2WPKH 서명 해시 계산
+		sigHash, err := txscript.CalcWitnessSigHash(prevOut.PkScript, sigHashes, txscript.SigHashAll, tx, i, prevOut.Value)
 		if err != nil {
-			return SendBitcoinResponse{
-				Success: false,
-				Message: fmt.Sprintf("서명 해시 계산 실패: %v", err),
-			}
+			return fmt.Errorf("서명 해시 계산 실패: %v", err)
 		}
 
 		// 서명 생성
@@ -1384,36 +1666,37 @@ func (a *App) SendBitcoinTransaction(request SendBitcoinRequest) SendBitcoinResp
 		// 서명에 SigHashAll 플래그 추가
 		sigWithFlag := append(signature.Serialize(), byte(txscript.SigHashAll))
 
-		// 공개키
-		pubKey := privKeyWIF.PrivKey.PubKey().SerializeCompressed()
-
 		// Witness 데이터 설정
 		tx.TxIn[i].Witness = wire.TxWitness{sigWithFlag, pubKey}
 	}
 
-	// 6. 거래 직렬화
-	var buf bytes.Buffer
-	if err := tx.Serialize(&buf); err != nil {
-		return SendBitcoinResponse{
-			Success: false,
-			Message: fmt.Sprintf("거래 직렬화 실패: %v", err),
-		}
+	return nil
+}
+
+// selectSendMaxUTXOs 전체 전송에 사용할 UTXO 선택 (목록이 비어있으면 전체 UTXO 사용)
+func selectSendMaxUTXOs(utxos []UTXO, outpoints []string) ([]UTXO, error) {
+	if len(outpoints) == 0 {
+		return utxos, nil
 	}
 
-	txHex := hex.EncodeToString(buf.Bytes())
+	byOutpoint := make(map[string]UTXO, len(utxos))
+	for _, utxo := range utxos {
+		byOutpoint[fmt.Sprintf("%s:%d", utxo.TxID, utxo.Vout)] = utxo
+	}
 
-	// 7. 거래 브로드캐스트
-	txHash, err := a.broadcastTransaction(txHex)
-	if err != nil {
-		return SendBitcoinResponse{
-			Success: false,
-			Message: fmt.Sprintf("거래 브로드캐스트 실패: %v", err),
+	var selected []UTXO
+	seen := make(map[string]bool)
+	for _, outpoint := range outpoints {
+		if seen[outpoint] {
+			continue
+		}
+		utxo, ok := byOutpoint[outpoint]
+		if !ok {
+			return nil, fmt.Errorf("사용할 수 없는 UTXO입니다: %s", outpoint)
 		}
+		seen[outpoint] = true
+		selected = append(selected, utxo)
 	}
 
-	return SendBitcoinResponse{
-		Success: true,
-		Message: "거래가 성공적으로 전송되었습니다",
-		TxHash:  txHash,
-	}
+	return selected, nil
 }
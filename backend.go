@@ -0,0 +1,328 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// configFileName 실행 파일 옆에 저장되는 설정 파일 이름 (USB에서 그대로 휴대)
+const configFileName = "wallet-config.json"
+
+// 백엔드 종류
+const (
+	BackendEsplora  = "esplora"
+	BackendElectrum = "electrum"
+	BackendCore     = "bitcoincore"
+	BackendFilter   = "compactfilter"
+)
+
+// defaultEsploraURL 기본 Esplora API 주소
+const defaultEsploraURL = "https://blockstream.info/api"
+
+// ChainBackend 블록체인 조회 및 거래 전송을 담당하는 백엔드
+// Esplora, Electrum, Bitcoin Core 등 서로 다른 서버를 같은 방식으로 사용하기 위한 인터페이스
+type ChainBackend interface {
+	// Name 백엔드 이름 (오류 메시지 및 비교 보고서용)
+	Name() string
+	// AddressUTXOs 주소의 모든 UTXO 조회 (미확인 UTXO 포함)
+	AddressUTXOs(address string) ([]UTXO, error)
+	// AddressStats 주소의 확인/미확인 입출금 통계 조회
+	AddressStats(address string) (*AddressStats, error)
+	// AddressTransactions 주소의 거래 내역 한 페이지 조회 (최신순, 첫 페이지는 미확인 거래 포함)
+	// lastSeenTxID 다음의 확인된 거래부터 반환하며, 빈 결과면 마지막 페이지
+	AddressTransactions(address, lastSeenTxID string) ([]TxDetails, error)
+	// Transaction 거래 세부정보 조회 (이전 출력 정보 포함)
+	Transaction(txid string) (*TxDetails, error)
+	// RawTransaction 직렬화된 원본 거래 조회 (서명 전 로컬 검증용)
+	RawTransaction(txid string) ([]byte, error)
+	// Broadcast 서명된 거래 hex 전송 후 거래 ID 반환
+	Broadcast(txHex string) (string, error)
+	// FeeEstimates 확인 목표 블록 수별 예상 수수료율 (sat/vB)
+	FeeEstimates() (map[int]float64, error)
+	// TipHeight 현재 최신 블록 높이
+	TipHeight() (int64, error)
+}
+
+// BackendConfig 블록체인 백엔드 설정
+type BackendConfig struct {
+	Type           string `json:"type"`           // 백엔드 종류 (esplora, electrum, bitcoincore, compactfilter)
+	URL            string `json:"url"`            // 서버 주소 (Electrum은 tcp://호스트:포트 또는 ssl://호스트:포트)
+	TLSSkipVerify  bool   `json:"tlsSkipVerify"`  // TLS 인증서 검증 생략 (자체 서명 인증서를 쓰는 개인 서버용)
+	RPCUser        string `json:"rpcUser"`        // Bitcoin Core RPC 사용자 이름
+	RPCPassword    string `json:"rpcPassword"`    // Bitcoin Core RPC 비밀번호
+	CookieFile     string `json:"cookieFile"`     // Bitcoin Core .cookie 파일 경로 (사용자/비밀번호 대신 사용)
+	Wallet         string `json:"wallet"`         // 주소를 가져온 watch-only 지갑 이름 (비어 있으면 scantxoutset 사용)
+	BirthdayHeight int64  `json:"birthdayHeight"` // 블록 필터 스캔 시작 높이 (지갑 생성 이전 블록은 건너뜀)
+}
+
+// AppConfig 앱 설정 (설정 파일에 저장)
+type AppConfig struct {
+	Backend BackendConfig `json:"backend"` // 블록체인 백엔드 설정
+	Proxy   ProxyConfig   `json:"proxy"`   // 모든 네트워크 요청에 사용할 SOCKS5 프록시 설정
+	Quorum  QuorumConfig  `json:"quorum"`  // 여러 백엔드 교차 검증 설정
+	SPV     SPVConfig     `json:"spv"`     // 블록 헤더와 Merkle 증명 검증 설정
+	P2P     P2PConfig     `json:"p2p"`     // 비트코인 P2P 노드 직접 브로드캐스트 설정
+}
+
+// BackendConfigResponse 백엔드 설정 응답 구조체
+type BackendConfigResponse struct {
+	Success   bool          `json:"success"`   // 성공 여부
+	Message   string        `json:"message"`   // 응답 메시지
+	ErrorCode string        `json:"errorCode"` // 에러 코드 (다국어 처리용)
+	Config    BackendConfig `json:"config"`    // 적용된 설정
+}
+
+// FeeEstimatesResponse 예상 수수료율 조회 응답 구조체
+type FeeEstimatesResponse struct {
+	Success   bool            `json:"success"`   // 성공 여부
+	Message   string          `json:"message"`   // 응답 메시지
+	ErrorCode string          `json:"errorCode"` // 에러 코드 (다국어 처리용)
+	Estimates map[int]float64 `json:"estimates"` // 확인 목표 블록 수별 수수료율 (sat/vB)
+	TipHeight int64           `json:"tipHeight"` // 현재 블록 높이
+}
+
+// defaultAppConfig 설정 파일이 없을 때 사용할 기본 설정
+func defaultAppConfig() AppConfig {
+	return AppConfig{
+		Backend: BackendConfig{
+			Type: BackendEsplora,
+			URL:  defaultEsploraURL,
+		},
+		Proxy: ProxyConfig{
+			Address: defaultProxyAddress,
+		},
+	}
+}
+
+// configFilePath 설정 파일 경로 (실행 파일과 같은 폴더)
+func configFilePath() string {
+	exePath, err := os.Executable()
+	if err != nil {
+		return configFileName
+	}
+	return filepath.Join(filepath.Dir(exePath), configFileName)
+}
+
+// loadAppConfig 설정 파일 읽기 (없거나 잘못된 경우 기본 설정)
+func loadAppConfig() AppConfig {
+	config := defaultAppConfig()
+
+	data, err := os.ReadFile(configFilePath())
+	if err != nil {
+		return config
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return defaultAppConfig()
+	}
+	return config
+}
+
+// saveAppConfig 설정 파일 저장
+func saveAppConfig(config AppConfig) error {
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(configFilePath(), data, 0600)
+}
+
+// newChainBackend 설정에 맞는 백엔드 생성 (모든 연결은 프록시 설정을 따르고, ctx가 취소되면 진행 중인 요청도 취소됨)
+func newChainBackend(ctx context.Context, appConfig AppConfig) (ChainBackend, error) {
+	dial, err := newDialer(appConfig.Proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	primary, err := newSingleBackend(ctx, appConfig.Backend, dial)
+	if err != nil {
+		return nil, err
+	}
+
+	backend := primary
+	if appConfig.Quorum.Enabled {
+		// 교차 검증 모드: 추가 백엔드와 결과를 비교
+		backends := []ChainBackend{primary}
+		for _, config := range appConfig.Quorum.Backends {
+			extra, err := newSingleBackend(ctx, config, dial)
+			if err != nil {
+				return nil, err
+			}
+			backends = append(backends, extra)
+		}
+		if len(backends) < 2 {
+			return nil, fmt.Errorf("교차 검증에는 추가 백엔드가 1개 이상 필요합니다")
+		}
+		backend = newQuorumBackend(backends)
+	}
+
+	backend, err = withSPV(backend, primary, appConfig.SPV)
+	if err != nil {
+		return nil, err
+	}
+	return withP2P(ctx, backend, appConfig.P2P, dial)
+}
+
+// withSPV SPV 검증이 켜져 있으면 백엔드를 검증 백엔드로 감쌈 (헤더와 증명은 기본 백엔드에서 조회)
+func withSPV(backend, primary ChainBackend, config SPVConfig) (ChainBackend, error) {
+	if !config.Enabled {
+		return backend, nil
+	}
+	return newSPVBackend(backend, primary, config)
+}
+
+// newSingleBackend 백엔드 설정 하나에 해당하는 백엔드 생성
+func newSingleBackend(ctx context.Context, config BackendConfig, dial dialContextFunc) (ChainBackend, error) {
+	switch config.Type {
+	case "", BackendEsplora:
+		baseURL := strings.TrimRight(strings.TrimSpace(config.URL), "/")
+		if baseURL == "" {
+			baseURL = defaultEsploraURL
+		}
+		parsed, err := url.Parse(baseURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("잘못된 Esplora 주소: %s", config.URL)
+		}
+		return newEsploraBackend(baseURL, newHTTPFetcher(ctx, newHTTPClient(dial))), nil
+	case BackendElectrum:
+		return newElectrumBackend(ctx, strings.TrimSpace(config.URL), config.TLSSkipVerify, dial)
+	case BackendCore:
+		return newBitcoinCoreBackend(strings.TrimSpace(config.URL), config.RPCUser, config.RPCPassword,
+			strings.TrimSpace(config.CookieFile), strings.TrimSpace(config.Wallet), newHTTPFetcher(ctx, newHTTPClient(dial)))
+	case BackendFilter:
+		// 필터와 블록은 REST로 받고, 인증 정보가 있으면 브로드캐스트와 수수료율은 같은 노드의 RPC 사용
+		fetcher := newHTTPFetcher(ctx, newHTTPClient(dial))
+		var node *bitcoinCoreBackend
+		if config.RPCUser != "" || config.CookieFile != "" {
+			var err error
+			node, err = newBitcoinCoreBackend(strings.TrimSpace(config.URL), config.RPCUser, config.RPCPassword,
+				strings.TrimSpace(config.CookieFile), "", fetcher)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return newCompactFilterBackend(strings.TrimSpace(config.URL), config.BirthdayHeight, node, fetcher)
+	}
+	return nil, fmt.Errorf("지원되지 않는 백엔드 종류: %s", config.Type)
+}
+
+// chain 현재 사용 중인 블록체인 백엔드
+func (a *App) chain() ChainBackend {
+	a.configMu.RLock()
+	defer a.configMu.RUnlock()
+	return a.backend
+}
+
+// applyConfig 설정을 적용하여 백엔드 교체
+func (a *App) applyConfig(config AppConfig) error {
+	backend, err := newChainBackend(a.ctx, config)
+	if err != nil {
+		return err
+	}
+
+	a.configMu.Lock()
+	defer a.configMu.Unlock()
+	a.config = config
+	a.backend = newCachedBackend(backend)
+	return nil
+}
+
+// applyStartupConfig 시작 시 저장된 설정 적용
+// 백엔드를 만들 수 없어도 불러온 설정(특히 프록시 설정)은 유지하고, 기본 Esplora 백엔드도 그 프록시 설정을 따름
+func (a *App) applyStartupConfig(config AppConfig) error {
+	err := a.applyConfig(config)
+	if err == nil {
+		return nil
+	}
+
+	a.configMu.Lock()
+	defer a.configMu.Unlock()
+	a.config = config
+	a.backend = newCachedBackend(fallbackBackend(a.ctx, config.Proxy))
+	return err
+}
+
+// fallbackBackend 저장된 백엔드 설정을 사용할 수 없을 때의 기본 Esplora 백엔드
+// 프록시 설정 자체가 잘못되었으면 직접 연결하지 않도록 모든 연결을 거부
+func fallbackBackend(ctx context.Context, config ProxyConfig) ChainBackend {
+	dial, err := newDialer(config)
+	if err != nil {
+		dialErr := err
+		dial = func(context.Context, string, string) (net.Conn, error) {
+			return nil, fmt.Errorf("%w: %v", errProxyUnavailable, dialErr)
+		}
+	}
+	return newEsploraBackend(defaultEsploraURL, newHTTPFetcher(ctx, newHTTPClient(dial)))
+}
+
+// GetBackendConfig 현재 블록체인 백엔드 설정 반환
+func (a *App) GetBackendConfig() BackendConfig {
+	a.configMu.RLock()
+	defer a.configMu.RUnlock()
+	return a.config.Backend
+}
+
+// SetBackendConfig 블록체인 백엔드 설정 변경 및 설정 파일 저장 (자체 Esplora/mempool 서버 등)
+func (a *App) SetBackendConfig(config BackendConfig) BackendConfigResponse {
+	a.configMu.RLock()
+	appConfig := a.config
+	a.configMu.RUnlock()
+
+	appConfig.Backend = config
+	if err := a.applyConfig(appConfig); err != nil {
+		return BackendConfigResponse{
+			Success:   false,
+			Message:   err.Error(),
+			ErrorCode: "BACKEND_CONFIG_INVALID",
+		}
+	}
+
+	if err := saveAppConfig(appConfig); err != nil {
+		return BackendConfigResponse{
+			Success: false,
+			Message: fmt.Sprintf("설정 파일 저장 실패: %v", err),
+			Config:  config,
+		}
+	}
+
+	return BackendConfigResponse{
+		Success: true,
+		Message: "백엔드 설정이 저장되었습니다",
+		Config:  config,
+	}
+}
+
+// GetFeeEstimates 현재 백엔드의 예상 수수료율 조회
+func (a *App) GetFeeEstimates() FeeEstimatesResponse {
+	backend := a.chain()
+
+	estimates, err := backend.FeeEstimates()
+	if err != nil {
+		return FeeEstimatesResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("수수료율 조회 실패: %v", err),
+			ErrorCode: backendErrorCode(err),
+		}
+	}
+
+	height, err := backend.TipHeight()
+	if err != nil {
+		return FeeEstimatesResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("블록 높이 조회 실패: %v", err),
+			ErrorCode: backendErrorCode(err),
+		}
+	}
+
+	return FeeEstimatesResponse{
+		Success:   true,
+		Message:   "성공",
+		Estimates: estimates,
+		TipHeight: height,
+	}
+}
@@ -0,0 +1,374 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	testWalletAddress    = "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"
+	testRecipientAddress = "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq"
+)
+
+// fakeBackend 테스트용 메모리 백엔드 (네트워크 없이 미리 넣어둔 데이터로 응답)
+type fakeBackend struct {
+	mu         sync.Mutex
+	utxos      map[string][]UTXO
+	txs        map[string]*TxDetails
+	raw        map[string][]byte
+	history    map[string][]TxDetails
+	fees       map[int]float64
+	tip        int64
+	err        error // 설정되면 모든 조회가 이 오류를 반환
+	broadcasts []string
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{
+		utxos:   make(map[string][]UTXO),
+		txs:     make(map[string]*TxDetails),
+		raw:     make(map[string][]byte),
+		history: make(map[string][]TxDetails),
+		fees:    map[int]float64{1: 20, 6: 10, 144: 2},
+		tip:     850000,
+	}
+}
+
+func (f *fakeBackend) Name() string { return "fake" }
+
+func (f *fakeBackend) AddressUTXOs(address string) ([]UTXO, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	return append([]UTXO(nil), f.utxos[address]...), nil
+}
+
+func (f *fakeBackend) AddressStats(address string) (*AddressStats, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	stats := &AddressStats{}
+	for _, utxo := range f.utxos[address] {
+		if utxo.Status.Confirmed {
+			stats.ChainStats.FundedTxoCount++
+			stats.ChainStats.FundedTxoSum += utxo.Value
+		} else {
+			stats.MempoolStats.FundedTxoCount++
+			stats.MempoolStats.FundedTxoSum += utxo.Value
+		}
+	}
+	return stats, nil
+}
+
+func (f *fakeBackend) AddressTransactions(address, lastSeenTxID string) ([]TxDetails, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	if lastSeenTxID != "" {
+		return nil, nil
+	}
+	return append([]TxDetails(nil), f.history[address]...), nil
+}
+
+func (f *fakeBackend) Transaction(txid string) (*TxDetails, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	tx, ok := f.txs[txid]
+	if !ok {
+		return nil, fmt.Errorf("거래를 찾을 수 없습니다: %s", txid)
+	}
+	return tx, nil
+}
+
+func (f *fakeBackend) RawTransaction(txid string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	raw, ok := f.raw[txid]
+	if !ok {
+		return nil, fmt.Errorf("거래를 찾을 수 없습니다: %s", txid)
+	}
+	return raw, nil
+}
+
+func (f *fakeBackend) Broadcast(txHex string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return "", f.err
+	}
+	f.broadcasts = append(f.broadcasts, txHex)
+	return "broadcast", nil
+}
+
+func (f *fakeBackend) FeeEstimates() (map[int]float64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.fees, nil
+}
+
+func (f *fakeBackend) TipHeight() (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return 0, f.err
+	}
+	return f.tip, nil
+}
+
+// newTestApp 가짜 백엔드를 사용하는 App
+func newTestApp(backend ChainBackend) *App {
+	app := NewApp()
+	app.backend = backend
+	return app
+}
+
+// testTxID 테스트용 거래 ID (n으로 구분되는 64자리 hex)
+func testTxID(n int) string {
+	return fmt.Sprintf("%064x", n)
+}
+
+func testUTXO(n int, value int64, confirmed bool) UTXO {
+	utxo := UTXO{TxID: testTxID(n), Vout: 0, Value: value}
+	utxo.Status.Confirmed = confirmed
+	if confirmed {
+		utxo.Status.BlockHeight = 849000
+	}
+	return utxo
+}
+
+func TestGetBalance(t *testing.T) {
+	backend := newFakeBackend()
+	backend.utxos[testWalletAddress] = []UTXO{
+		testUTXO(1, 10_000, true),
+		testUTXO(2, 50_000, true),
+		testUTXO(3, 200_000, false),
+	}
+	app := newTestApp(backend)
+
+	response := app.GetBalance(GetBalanceRequest{Address: testWalletAddress})
+	if !response.Success {
+		t.Fatalf("GetBalance failed: %s", response.Message)
+	}
+	if response.ConfirmedSat != 60_000 || response.UnconfirmedSat != 200_000 || response.BalanceSat != 260_000 {
+		t.Errorf("balance = %d confirmed + %d unconfirmed = %d, want 60000 + 200000 = 260000",
+			response.ConfirmedSat, response.UnconfirmedSat, response.BalanceSat)
+	}
+	if response.UTXOCount != 2 {
+		t.Errorf("UTXOCount = %d, want 2 confirmed", response.UTXOCount)
+	}
+
+	if response := app.GetBalance(GetBalanceRequest{}); response.Success {
+		t.Error("GetBalance without address should fail")
+	}
+
+	backend.err = &backendError{code: ErrorCodeBackendUnavailable, err: fmt.Errorf("연결 실패")}
+	response = app.GetBalance(GetBalanceRequest{Address: testWalletAddress})
+	if response.Success || response.ErrorCode != ErrorCodeBackendUnavailable {
+		t.Errorf("GetBalance with backend error = %+v, want %s", response, ErrorCodeBackendUnavailable)
+	}
+}
+
+func TestBuildTransactionPlanCoinSelection(t *testing.T) {
+	backend := newFakeBackend()
+	backend.utxos[testWalletAddress] = []UTXO{
+		testUTXO(1, 10_000, true),
+		testUTXO(2, 50_000, true),
+		testUTXO(3, 200_000, false), // 미확인 UTXO는 선택하지 않음
+	}
+	app := newTestApp(backend)
+
+	tests := []struct {
+		name          string
+		amount        int64
+		sendMax       bool
+		selected      []string
+		wantInputs    []string
+		wantAmount    int64
+		wantChange    int64
+		wantMinerFee  int64
+		wantAbsorbed  int64
+		wantErrSubstr string
+	}{
+		{
+			name:         "largest confirmed first",
+			amount:       40_000,
+			wantInputs:   []string{testTxID(2)},
+			wantAmount:   40_000,
+			wantChange:   8_000,
+			wantMinerFee: 2_000,
+		},
+		{
+			name:         "adds next largest when needed",
+			amount:       55_000,
+			wantInputs:   []string{testTxID(2), testTxID(1)},
+			wantAmount:   55_000,
+			wantChange:   3_000,
+			wantMinerFee: 2_000,
+		},
+		{
+			name:         "dust change absorbed as fee",
+			amount:       47_500,
+			wantInputs:   []string{testTxID(2)},
+			wantAmount:   47_500,
+			wantMinerFee: 2_500,
+			wantAbsorbed: 500,
+		},
+		{
+			name:          "unconfirmed funds are not spendable",
+			amount:        59_000,
+			wantErrSubstr: "잔액이 부족합니다",
+		},
+		{
+			name:         "send max with selected UTXO",
+			sendMax:      true,
+			selected:     []string{testTxID(1) + ":0"},
+			wantInputs:   []string{testTxID(1)},
+			wantAmount:   8_000,
+			wantMinerFee: 2_000,
+		},
+		{
+			name:          "send max with unknown UTXO",
+			sendMax:       true,
+			selected:      []string{testTxID(3) + ":0"},
+			wantErrSubstr: "사용할 수 없는 UTXO",
+		},
+	}
+
+	for _, tt := range tests {
+		plan, failure := app.buildTransactionPlan(SendBitcoinRequest{
+			WalletData:       WalletData{Address: testWalletAddress},
+			RecipientAddress: testRecipientAddress,
+			AmountSat:        tt.amount,
+			FeeSatoshi:       2_000,
+			SendMax:          tt.sendMax,
+			SelectedUTXOs:    tt.selected,
+		})
+
+		if tt.wantErrSubstr != "" {
+			if plan != nil || !strings.Contains(failure.Message, tt.wantErrSubstr) {
+				t.Errorf("%s: got plan=%v message=%q, want error containing %q", tt.name, plan != nil, failure.Message, tt.wantErrSubstr)
+			}
+			continue
+		}
+		if plan == nil {
+			t.Errorf("%s: buildTransactionPlan failed: %s", tt.name, failure.Message)
+			continue
+		}
+
+		var inputs []string
+		for _, utxo := range plan.inputs {
+			inputs = append(inputs, utxo.TxID)
+		}
+		if strings.Join(inputs, ",") != strings.Join(tt.wantInputs, ",") {
+			t.Errorf("%s: inputs = %v, want %v", tt.name, inputs, tt.wantInputs)
+		}
+
+		var change int64
+		for _, output := range plan.outputs {
+			if output.IsChange {
+				change += output.AmountSat
+			}
+		}
+		if plan.amountSat != tt.wantAmount || change != tt.wantChange ||
+			plan.minerFeeSat != tt.wantMinerFee || plan.absorbedSat != tt.wantAbsorbed {
+			t.Errorf("%s: amount=%d change=%d fee=%d absorbed=%d, want %d/%d/%d/%d", tt.name,
+				plan.amountSat, change, plan.minerFeeSat, plan.absorbedSat,
+				tt.wantAmount, tt.wantChange, tt.wantMinerFee, tt.wantAbsorbed)
+		}
+		if len(plan.tx.TxIn) != len(tt.wantInputs) || len(plan.tx.TxOut) != len(plan.outputs) {
+			t.Errorf("%s: tx has %d inputs/%d outputs, plan has %d/%d", tt.name,
+				len(plan.tx.TxIn), len(plan.tx.TxOut), len(tt.wantInputs), len(plan.outputs))
+		}
+	}
+}
+
+func TestStartupConfigFailureKeepsProxy(t *testing.T) {
+	tests := []struct {
+		name  string
+		proxy ProxyConfig
+	}{
+		// 127.0.0.1:1에는 프록시가 없으므로 직접 연결로 대체되면 안 됨
+		{"required proxy unreachable", ProxyConfig{Enabled: true, Address: "127.0.0.1:1", Required: true}},
+		{"invalid proxy address", ProxyConfig{Enabled: true, Address: "no-port"}},
+	}
+
+	for _, tt := range tests {
+		app := NewApp()
+		config := defaultAppConfig()
+		config.Backend = BackendConfig{Type: "unknown"}
+		config.Proxy = tt.proxy
+
+		if err := app.applyStartupConfig(config); err == nil {
+			t.Fatalf("%s: applyStartupConfig should report the invalid backend", tt.name)
+		}
+		if app.GetBackendConfig().Type != "unknown" || app.config.Proxy != tt.proxy {
+			t.Errorf("%s: loaded config was not kept: %+v", tt.name, app.config)
+		}
+
+		// 브로드캐스트는 재시도하지 않으므로 연결 거부가 바로 드러남
+		_, err := app.chain().Broadcast("00")
+		if err == nil || backendErrorCode(err) != ErrorCodeProxyUnavailable {
+			t.Errorf("%s: fallback backend Broadcast = %v (code %q), want %s", tt.name, err, backendErrorCode(err), ErrorCodeProxyUnavailable)
+		}
+	}
+}
+
+func TestSendMaxFeeScalesWithInputs(t *testing.T) {
+	backend := newFakeBackend()
+	for i := 1; i <= 20; i++ {
+		backend.utxos[testWalletAddress] = append(backend.utxos[testWalletAddress], testUTXO(i, 10_000, true))
+	}
+	app := newTestApp(backend)
+	request := SendBitcoinRequest{
+		WalletData:       WalletData{Address: testWalletAddress},
+		RecipientAddress: testRecipientAddress,
+		FeeSatoshi:       2_000,
+		SendMax:          true,
+	}
+
+	// 수수료율을 지정하면 입력 20개의 크기로 수수료 계산
+	request.FeeRate = 3
+	plan, failure := app.buildTransactionPlan(request)
+	if plan == nil {
+		t.Fatalf("buildTransactionPlan: %s", failure.Message)
+	}
+	vsize := estimateVSize(plan.tx)
+	if want := int64(math.Ceil(3 * float64(vsize))); plan.minerFeeSat != want || plan.amountSat != 200_000-want {
+		t.Errorf("fee=%d amount=%d, want fee %d for %d vB", plan.minerFeeSat, plan.amountSat, want, vsize)
+	}
+	if plan.tx.TxOut[0].Value != plan.amountSat || plan.outputs[0].AmountSat != plan.amountSat {
+		t.Errorf("recipient output = %d, plan output = %d, want %d", plan.tx.TxOut[0].Value, plan.outputs[0].AmountSat, plan.amountSat)
+	}
+
+	// 고정 수수료만 있으면 수수료율이 백엔드의 가장 낮은 예상치(2 sat/vB)보다 낮다고 경고
+	request.FeeRate = 0
+	response := app.PrepareTransaction(request)
+	if !response.Success {
+		t.Fatalf("PrepareTransaction: %s", response.Message)
+	}
+	found := false
+	for _, warning := range response.Warnings {
+		found = found || warning.Code == "LOW_FEE_RATE"
+	}
+	if response.FeeSat != 2_000 || !found {
+		t.Errorf("fee=%d (%.2f sat/vB) warnings=%+v, want LOW_FEE_RATE", response.FeeSat, response.FeeRate, response.Warnings)
+	}
+}
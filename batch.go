@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// BatchRecipient 일괄 전송의 개별 수신자 정보
+type BatchRecipient struct {
+	Address   string `json:"address"`   // 받는 주소
+	AmountSat int64  `json:"amountSat"` // 전송 금액 (satoshi)
+	Label     string `json:"label"`     // 메모 (선택)
+}
+
+// ImportBatchCSVResponse CSV 일괄 전송 목록 가져오기 응답 구조체
+type ImportBatchCSVResponse struct {
+	Success        bool             `json:"success"`        // 성공 여부
+	Message        string           `json:"message"`        // 응답 메시지
+	ErrorCode      string           `json:"errorCode"`      // 에러 코드 (다국어 처리용)
+	Recipients     []BatchRecipient `json:"recipients"`     // 수신자 목록
+	TotalAmountSat int64            `json:"totalAmountSat"` // 전송 금액 합계 (satoshi)
+}
+
+// paymentOutput 검증이 끝난 거래 출력 (주소, 금액, 스크립트)
+type paymentOutput struct {
+	Address   string
+	AmountSat int64
+	Script    []byte
+	Label     string
+}
+
+// validateBatchRecipients 일괄 전송 수신자 목록 검증 (주소 형식, 중복, 더스트 한도)
+// 실패 시 다국어 처리를 위한 에러 코드를 함께 반환
+func validateBatchRecipients(recipients []BatchRecipient) ([]paymentOutput, string, error) {
+	if len(recipients) == 0 {
+		return nil, "BATCH_EMPTY", fmt.Errorf("수신자 목록이 비어있습니다")
+	}
+
+	outputs := make([]paymentOutput, 0, len(recipients))
+	seen := make(map[string]int)
+	for i, recipient := range recipients {
+		address := strings.TrimSpace(recipient.Address)
+		if address == "" {
+			return nil, "BATCH_ADDRESS_EMPTY", fmt.Errorf("%d번째 수신자의 주소가 비어있습니다", i+1)
+		}
+
+		decoded, err := btcutil.DecodeAddress(address, &chaincfg.MainNetParams)
+		if err != nil {
+			return nil, "BATCH_ADDRESS_INVALID", fmt.Errorf("%d번째 수신자 주소 형식 오류: %v", i+1, err)
+		}
+
+		// 같은 주소로 여러 번 보내는 실수 방지 (인코딩 차이 무시)
+		normalized := decoded.EncodeAddress()
+		if prev, ok := seen[normalized]; ok {
+			return nil, "BATCH_DUPLICATE_ADDRESS", fmt.Errorf("%d번째 수신자 주소가 %d번째와 중복됩니다: %s", i+1, prev+1, address)
+		}
+		seen[normalized] = i
+
+		amountSat := recipient.AmountSat
+		if amountSat < 546 {
+			return nil, "AMOUNT_TOO_SMALL", fmt.Errorf("%d번째 수신자 전송 금액이 너무 작습니다. 최소 546 사토시가 필요합니다", i+1)
+		}
+
+		script, err := txscript.PayToAddrScript(decoded)
+		if err != nil {
+			return nil, "BATCH_ADDRESS_INVALID", fmt.Errorf("%d번째 수신자 스크립트 생성 실패: %v", i+1, err)
+		}
+
+		outputs = append(outputs, paymentOutput{
+			Address:   address,
+			AmountSat: amountSat,
+			Script:    script,
+			Label:     recipient.Label,
+		})
+	}
+
+	return outputs, "", nil
+}
+
+// parseBatchCSV CSV 데이터를 수신자 목록으로 변환
+// 형식: address,amount[,label] (금액 기본 단위 BTC, "500 sats"처럼 단위 지정 가능)
+// 첫 줄이 헤더이면 건너뛰고, '#'으로 시작하는 줄은 주석으로 처리
+func parseBatchCSV(r io.Reader) ([]BatchRecipient, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	var recipients []BatchRecipient
+	line := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CSV 파싱 실패: %v", err)
+		}
+		line++
+
+		if len(record) < 2 {
+			return nil, fmt.Errorf("%d번째 줄: 주소와 금액이 필요합니다", line)
+		}
+
+		address := strings.TrimSpace(record[0])
+		amountField := strings.TrimSpace(record[1])
+		amountSat, err := parseAmount(amountField, UnitBTC)
+		if err != nil {
+			// 첫 줄의 금액이 숫자가 아니면 헤더로 간주
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("%d번째 줄: 잘못된 금액 %q", line, amountField)
+		}
+
+		label := ""
+		if len(record) > 2 {
+			label = strings.TrimSpace(record[2])
+		}
+
+		recipients = append(recipients, BatchRecipient{
+			Address:   address,
+			AmountSat: amountSat,
+			Label:     label,
+		})
+	}
+
+	return recipients, nil
+}
+
+// ImportBatchCSV CSV 파일에서 일괄 전송 수신자 목록 가져오기 및 검증
+func (a *App) ImportBatchCSV(filePath string) ImportBatchCSVResponse {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return ImportBatchCSVResponse{
+			Success: false,
+			Message: "CSV 파일을 열 수 없습니다: " + err.Error(),
+		}
+	}
+	defer file.Close()
+
+	recipients, err := parseBatchCSV(file)
+	if err != nil {
+		return ImportBatchCSVResponse{
+			Success:   false,
+			Message:   err.Error(),
+			ErrorCode: "BATCH_CSV_INVALID",
+		}
+	}
+
+	outputs, errorCode, err := validateBatchRecipients(recipients)
+	if err != nil {
+		return ImportBatchCSVResponse{
+			Success:    false,
+			Message:    err.Error(),
+			ErrorCode:  errorCode,
+			Recipients: recipients,
+		}
+	}
+
+	var totalSat int64
+	for _, output := range outputs {
+		totalSat += output.AmountSat
+	}
+
+	return ImportBatchCSVResponse{
+		Success:        true,
+		Message:        fmt.Sprintf("%d명의 수신자를 가져왔습니다", len(recipients)),
+		Recipients:     recipients,
+		TotalAmountSat: totalSat,
+	}
+}
+
+// SelectBatchCSVFile 일괄 전송 CSV 파일 선택 대화상자 표시
+func (a *App) SelectBatchCSVFile() (string, error) {
+	selectedPath, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "일괄 전송 CSV 파일 선택",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "CSV 파일 (*.csv)",
+				Pattern:     "*.csv",
+			},
+			{
+				DisplayName: "모든 파일 (*.*)",
+				Pattern:     "*.*",
+			},
+		},
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	// 사용자가 취소를 선택한 경우
+	if selectedPath == "" {
+		return "", fmt.Errorf("파일 선택이 취소되었습니다")
+	}
+
+	return selectedPath, nil
+}
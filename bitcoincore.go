@@ -0,0 +1,469 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// bitcoinCoreBackend Bitcoin Core JSON-RPC 백엔드 (자체 풀노드)
+// 지갑 이름이 있으면 주소를 가져온 watch-only 디스크립터 지갑의 listunspent를,
+// 없으면 scantxoutset으로 UTXO 집합을 직접 스캔함 (scantxoutset은 멤풀 미포함)
+type bitcoinCoreBackend struct {
+	rpcURL     string
+	user       string
+	password   string
+	cookieFile string
+	wallet     string
+	fetcher    *httpFetcher
+
+	mu     sync.Mutex
+	nextID int
+}
+
+// bitcoinCoreRPCError Bitcoin Core RPC 오류
+type bitcoinCoreRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// newBitcoinCoreBackend Bitcoin Core 백엔드 생성 (rpcURL 예: http://127.0.0.1:8332)
+// 쿠키 파일이 지정되면 요청마다 읽어서 인증 (노드 재시작 시 쿠키가 바뀜), 아니면 사용자/비밀번호 인증
+func newBitcoinCoreBackend(rpcURL, user, password, cookieFile, wallet string, fetcher *httpFetcher) (*bitcoinCoreBackend, error) {
+	parsed, err := url.Parse(rpcURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("잘못된 Bitcoin Core RPC 주소: %s", rpcURL)
+	}
+	if cookieFile == "" && user == "" {
+		return nil, fmt.Errorf("RPC 쿠키 파일 또는 사용자 이름을 입력해주세요")
+	}
+
+	return &bitcoinCoreBackend{
+		rpcURL:     strings.TrimRight(rpcURL, "/"),
+		user:       user,
+		password:   password,
+		cookieFile: cookieFile,
+		wallet:     wallet,
+		fetcher:    fetcher,
+	}, nil
+}
+
+// Name 백엔드 이름
+func (b *bitcoinCoreBackend) Name() string {
+	return "bitcoincore(" + b.rpcURL + ")"
+}
+
+// credentials RPC 인증 정보 (쿠키 파일 우선)
+func (b *bitcoinCoreBackend) credentials() (string, string, error) {
+	if b.cookieFile == "" {
+		return b.user, b.password, nil
+	}
+
+	data, err := os.ReadFile(b.cookieFile)
+	if err != nil {
+		return "", "", fmt.Errorf("RPC 쿠키 파일 읽기 실패: %v", err)
+	}
+	user, password, ok := strings.Cut(strings.TrimSpace(string(data)), ":")
+	if !ok {
+		return "", "", fmt.Errorf("잘못된 RPC 쿠키 파일 형식")
+	}
+	return user, password, nil
+}
+
+// call RPC 호출 후 결과를 result에 디코딩 (useWallet이면 /wallet/<이름> 경로로 호출)
+func (b *bitcoinCoreBackend) call(result interface{}, useWallet bool, method string, params ...interface{}) error {
+	b.mu.Lock()
+	b.nextID++
+	id := b.nextID
+	b.mu.Unlock()
+
+	if params == nil {
+		params = []interface{}{}
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "1.0",
+		"id":      id,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	endpoint := b.rpcURL
+	if useWallet && b.wallet != "" {
+		endpoint += "/wallet/" + url.PathEscape(b.wallet)
+	}
+
+	// sendrawtransaction 외의 호출은 조회이므로 일시적 오류 시 재시도
+	// scantxoutset은 UTXO 집합 전체를 스캔하므로 시간 제한을 넉넉히 둠
+	request := httpRequest{
+		method:     http.MethodPost,
+		url:        endpoint,
+		body:       payload,
+		idempotent: method != "sendrawtransaction",
+		prepare: func(req *http.Request) error {
+			user, password, err := b.credentials()
+			if err != nil {
+				return err
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.SetBasicAuth(user, password)
+			return nil
+		},
+	}
+	if method == "scantxoutset" {
+		request.timeout = 5 * time.Minute
+	}
+
+	status, body, err := b.fetcher.do(request)
+	if err != nil {
+		return fmt.Errorf("Bitcoin Core 연결 실패: %w", err)
+	}
+
+	if status == http.StatusUnauthorized || status == http.StatusForbidden {
+		return fmt.Errorf("Bitcoin Core RPC 인증 실패")
+	}
+
+	// RPC 오류도 500 상태 코드와 함께 JSON 본문으로 전달됨
+	var rpcResp struct {
+		Result json.RawMessage      `json:"result"`
+		Error  *bitcoinCoreRPCError `json:"error"`
+	}
+	if err := json.Unmarshal(body, &rpcResp); err != nil {
+		return statusError(status, nil)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("Bitcoin Core 오류 %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(rpcResp.Result, result); err != nil {
+		return fmt.Errorf("Bitcoin Core 응답 파싱 실패 (%s): %v", method, err)
+	}
+	return nil
+}
+
+// btcToSat BTC 단위 RPC 금액을 satoshi로 변환
+func btcToSat(amount float64) (int64, error) {
+	value, err := btcutil.NewAmount(amount)
+	if err != nil {
+		return 0, fmt.Errorf("잘못된 금액: %v", err)
+	}
+	return int64(value), nil
+}
+
+// AddressUTXOs 주소의 모든 UTXO 조회
+func (b *bitcoinCoreBackend) AddressUTXOs(address string) ([]UTXO, error) {
+	if b.wallet != "" {
+		return b.walletUTXOs(address)
+	}
+	return b.scanUTXOs(address)
+}
+
+// walletUTXOs watch-only 지갑의 listunspent로 UTXO 조회 (미확인 UTXO 포함)
+func (b *bitcoinCoreBackend) walletUTXOs(address string) ([]UTXO, error) {
+	var info struct {
+		IsMine      bool `json:"ismine"`
+		IsWatchOnly bool `json:"iswatchonly"`
+	}
+	if err := b.call(&info, true, "getaddressinfo", address); err != nil {
+		return nil, fmt.Errorf("UTXO 조회 실패: %w", err)
+	}
+	if !info.IsMine && !info.IsWatchOnly {
+		return nil, fmt.Errorf("지갑 %s에 주소가 없습니다. importdescriptors로 addr(%s) 디스크립터를 먼저 가져와주세요", b.wallet, address)
+	}
+
+	tip, err := b.TipHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	var unspent []struct {
+		TxID          string  `json:"txid"`
+		Vout          int     `json:"vout"`
+		Amount        float64 `json:"amount"`
+		Confirmations int64   `json:"confirmations"`
+	}
+	if err := b.call(&unspent, true, "listunspent", 0, 9999999, []string{address}, true); err != nil {
+		return nil, fmt.Errorf("UTXO 조회 실패: %w", err)
+	}
+
+	utxos := make([]UTXO, 0, len(unspent))
+	for _, item := range unspent {
+		value, err := btcToSat(item.Amount)
+		if err != nil {
+			return nil, err
+		}
+		utxo := UTXO{TxID: item.TxID, Vout: item.Vout, Value: value}
+		if item.Confirmations > 0 {
+			utxo.Status.Confirmed = true
+			utxo.Status.BlockHeight = tip - item.Confirmations + 1
+		}
+		utxos = append(utxos, utxo)
+	}
+
+	return utxos, nil
+}
+
+// scanUTXOs scantxoutset으로 UTXO 집합 스캔 (확인된 UTXO만 조회됨)
+func (b *bitcoinCoreBackend) scanUTXOs(address string) ([]UTXO, error) {
+	var scan struct {
+		Success  bool `json:"success"`
+		Unspents []struct {
+			TxID   string  `json:"txid"`
+			Vout   int     `json:"vout"`
+			Amount float64 `json:"amount"`
+			Height int64   `json:"height"`
+		} `json:"unspents"`
+	}
+	if err := b.call(&scan, false, "scantxoutset", "start", []string{"addr(" + address + ")"}); err != nil {
+		return nil, fmt.Errorf("UTXO 조회 실패: %w", err)
+	}
+	if !scan.Success {
+		return nil, fmt.Errorf("UTXO 조회 실패: UTXO 집합 스캔이 완료되지 않았습니다")
+	}
+
+	utxos := make([]UTXO, 0, len(scan.Unspents))
+	for _, item := range scan.Unspents {
+		value, err := btcToSat(item.Amount)
+		if err != nil {
+			return nil, err
+		}
+		utxo := UTXO{TxID: item.TxID, Vout: item.Vout, Value: value}
+		utxo.Status.Confirmed = true
+		utxo.Status.BlockHeight = item.Height
+		utxos = append(utxos, utxo)
+	}
+
+	return utxos, nil
+}
+
+// AddressStats 주소 통계 조회
+// Bitcoin Core는 주소 인덱스가 없으므로 현재 UTXO 합계를 funded 합계로 기록
+func (b *bitcoinCoreBackend) AddressStats(address string) (*AddressStats, error) {
+	utxos, err := b.AddressUTXOs(address)
+	if err != nil {
+		return nil, fmt.Errorf("주소 통계 조회 실패: %w", err)
+	}
+
+	stats := &AddressStats{}
+	for _, utxo := range utxos {
+		if utxo.Status.Confirmed {
+			stats.ChainStats.FundedTxoCount++
+			stats.ChainStats.FundedTxoSum += utxo.Value
+		} else {
+			stats.MempoolStats.FundedTxoCount++
+			stats.MempoolStats.FundedTxoSum += utxo.Value
+		}
+	}
+
+	return stats, nil
+}
+
+// AddressTransactions 주소의 거래 내역 조회 (watch-only 지갑 필요, 한 페이지)
+func (b *bitcoinCoreBackend) AddressTransactions(address, lastSeenTxID string) ([]TxDetails, error) {
+	if b.wallet == "" {
+		return nil, fmt.Errorf("Bitcoin Core 백엔드에서 거래 내역을 조회하려면 지갑 이름을 설정해주세요")
+	}
+	if lastSeenTxID != "" {
+		return nil, nil
+	}
+
+	var entries []struct {
+		TxID string `json:"txid"`
+	}
+	if err := b.call(&entries, true, "listtransactions", "*", 1000000, 0, true); err != nil {
+		return nil, fmt.Errorf("거래 내역 조회 실패: %w", err)
+	}
+
+	// 보내기 항목의 address는 받는 사람 주소이므로 항목의 주소로 거르지 않고,
+	// 지갑에 다른 주소가 있을 수 있으므로 거래의 입출력에 이 주소가 있는지로 거름
+	seen := make(map[string]bool)
+	var txids []string
+	for _, entry := range entries {
+		if !seen[entry.TxID] {
+			seen[entry.TxID] = true
+			txids = append(txids, entry.TxID)
+		}
+	}
+	txs, err := transactionsByID(txids, b.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	related := txs[:0]
+	for _, tx := range txs {
+		if txTouchesAddress(tx, address) {
+			related = append(related, tx)
+		}
+	}
+	return related, nil
+}
+
+// txTouchesAddress 거래의 이전 출력이나 출력에 주소가 있는지 확인
+func txTouchesAddress(tx TxDetails, address string) bool {
+	for _, vin := range tx.Vin {
+		if vin.Prevout != nil && vin.Prevout.ScriptPubKeyAddress == address {
+			return true
+		}
+	}
+	for _, vout := range tx.Vout {
+		if vout.ScriptPubKeyAddress == address {
+			return true
+		}
+	}
+	return false
+}
+
+// bitcoinCoreTx getrawtransaction/gettransaction 공통 결과
+type bitcoinCoreTx struct {
+	Hex       string `json:"hex"`
+	BlockHash string `json:"blockhash"`
+	BlockTime int64  `json:"blocktime"`
+}
+
+// rawTransaction 원시 거래 조회 (txindex가 없으면 지갑 거래로 대체)
+func (b *bitcoinCoreBackend) rawTransaction(txid string) (*wire.MsgTx, *bitcoinCoreTx, error) {
+	var result bitcoinCoreTx
+	err := b.call(&result, false, "getrawtransaction", txid, true)
+	if err != nil && b.wallet != "" {
+		err = b.call(&result, true, "gettransaction", txid, true)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	raw, err := hex.DecodeString(result.Hex)
+	if err != nil {
+		return nil, nil, fmt.Errorf("거래 hex 디코딩 실패: %v", err)
+	}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	if err := tx.Deserialize(bytes.NewReader(raw)); err != nil {
+		return nil, nil, fmt.Errorf("거래 파싱 실패: %v", err)
+	}
+	return tx, &result, nil
+}
+
+// RawTransaction 직렬화된 원본 거래 조회 (txindex가 없으면 지갑 거래로 대체)
+func (b *bitcoinCoreBackend) RawTransaction(txid string) ([]byte, error) {
+	var rawHex string
+	err := b.call(&rawHex, false, "getrawtransaction", txid, false)
+	if err != nil && b.wallet != "" {
+		var result bitcoinCoreTx
+		if err = b.call(&result, true, "gettransaction", txid, true); err == nil {
+			rawHex = result.Hex
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("원본 거래 조회 실패: %w", err)
+	}
+
+	raw, err := hex.DecodeString(rawHex)
+	if err != nil {
+		return nil, fmt.Errorf("거래 hex 디코딩 실패: %v", err)
+	}
+	return raw, nil
+}
+
+// Transaction 거래 세부정보 조회 (이전 거래는 txindex 또는 지갑에 있어야 조회 가능)
+func (b *bitcoinCoreBackend) Transaction(txid string) (*TxDetails, error) {
+	tx, result, err := b.rawTransaction(txid)
+	if err != nil {
+		return nil, fmt.Errorf("거래 세부정보 조회 실패: %w", err)
+	}
+
+	details := txDetailsFromMsgTx(tx)
+
+	var totalInput, totalOutput int64
+	for i, txIn := range tx.TxIn {
+		if blockchainIsCoinbase(txIn) {
+			continue
+		}
+		prevTx, _, err := b.rawTransaction(txIn.PreviousOutPoint.Hash.String())
+		if err != nil {
+			return nil, fmt.Errorf("이전 거래 조회 실패: %w", err)
+		}
+		if int(txIn.PreviousOutPoint.Index) >= len(prevTx.TxOut) {
+			return nil, fmt.Errorf("잘못된 이전 출력 인덱스: %s:%d", txIn.PreviousOutPoint.Hash, txIn.PreviousOutPoint.Index)
+		}
+		prevOut := txOutputFromWire(prevTx.TxOut[txIn.PreviousOutPoint.Index])
+		details.Vin[i].Prevout = &prevOut
+		totalInput += prevOut.Value
+	}
+	for _, txOut := range tx.TxOut {
+		totalOutput += txOut.Value
+	}
+	if totalInput > 0 {
+		details.Fee = totalInput - totalOutput
+	}
+
+	if result.BlockHash != "" {
+		var header struct {
+			Height int64 `json:"height"`
+			Time   int64 `json:"time"`
+		}
+		// 블록 해시가 있는 거래를 미확인으로 낮추지 않도록 헤더 조회 실패는 오류로 반환
+		if err := b.call(&header, false, "getblockheader", result.BlockHash, true); err != nil {
+			return nil, fmt.Errorf("블록 헤더 조회 실패: %w", err)
+		}
+		details.Status.Confirmed = true
+		details.Status.BlockHash = result.BlockHash
+		details.Status.BlockHeight = header.Height
+		details.Status.BlockTime = header.Time
+	}
+
+	return details, nil
+}
+
+// Broadcast 거래 브로드캐스트
+func (b *bitcoinCoreBackend) Broadcast(txHex string) (string, error) {
+	var txid string
+	if err := b.call(&txid, false, "sendrawtransaction", txHex); err != nil {
+		return "", fmt.Errorf("브로드캐스트 오류: %w", err)
+	}
+	return txid, nil
+}
+
+// FeeEstimates 확인 목표 블록 수별 예상 수수료율 조회 (BTC/kvB를 sat/vB로 변환)
+func (b *bitcoinCoreBackend) FeeEstimates() (map[int]float64, error) {
+	estimates := make(map[int]float64)
+	for _, target := range []int{1, 2, 3, 6, 12, 24, 144} {
+		var estimate struct {
+			FeeRate float64  `json:"feerate"`
+			Errors  []string `json:"errors"`
+		}
+		if err := b.call(&estimate, false, "estimatesmartfee", target); err != nil {
+			return nil, fmt.Errorf("수수료율 조회 실패: %w", err)
+		}
+		// 데이터가 부족하면 feerate 없이 errors만 반환
+		if estimate.FeeRate <= 0 {
+			continue
+		}
+		estimates[target] = math.Round(estimate.FeeRate*1e8/1000*1000) / 1000
+	}
+	return estimates, nil
+}
+
+// TipHeight 최신 블록 높이 조회
+func (b *bitcoinCoreBackend) TipHeight() (int64, error) {
+	var height int64
+	if err := b.call(&height, false, "getblockcount"); err != nil {
+		return 0, fmt.Errorf("블록 높이 조회 실패: %w", err)
+	}
+	return height, nil
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+)
+
+// coreRPCCall 모의 RPC 서버가 받은 호출
+type coreRPCCall struct {
+	Path   string
+	Method string
+	Params []json.RawMessage
+}
+
+// coreRPCStub httptest로 띄운 모의 Bitcoin Core JSON-RPC 서버
+type coreRPCStub struct {
+	server *httptest.Server
+	// handle 호출별 응답 (result, RPC 오류 중 하나)
+	handle func(call coreRPCCall) (interface{}, *bitcoinCoreRPCError)
+
+	mu       sync.Mutex
+	user     string
+	password string
+	calls    []coreRPCCall
+}
+
+func newCoreRPCStub(t *testing.T, user, password string, handle func(call coreRPCCall) (interface{}, *bitcoinCoreRPCError)) *coreRPCStub {
+	stub := &coreRPCStub{handle: handle, user: user, password: password}
+	stub.server = httptest.NewServer(http.HandlerFunc(stub.serveHTTP))
+	t.Cleanup(stub.server.Close)
+	return stub
+}
+
+func (s *coreRPCStub) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	user, password, ok := r.BasicAuth()
+	s.mu.Lock()
+	authorized := ok && user == s.user && password == s.password
+	s.mu.Unlock()
+	if !authorized {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		ID     int               `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	call := coreRPCCall{Path: r.URL.Path, Method: req.Method, Params: req.Params}
+	s.mu.Lock()
+	s.calls = append(s.calls, call)
+	s.mu.Unlock()
+
+	result, rpcErr := s.handle(call)
+	// Bitcoin Core는 RPC 오류를 500 상태 코드와 함께 반환함
+	if rpcErr != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"id": req.ID, "result": result, "error": rpcErr})
+}
+
+// setAuth 노드 재시작으로 쿠키가 바뀐 상황을 흉내냄
+func (s *coreRPCStub) setAuth(user, password string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.user, s.password = user, password
+}
+
+// methods 받은 호출을 순서대로 "경로 메서드" 형식으로 반환
+func (s *coreRPCStub) methods() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	methods := make([]string, 0, len(s.calls))
+	for _, call := range s.calls {
+		methods = append(methods, call.Path+" "+call.Method)
+	}
+	return methods
+}
+
+// writeCookie "사용자:비밀번호" 형식의 쿠키 파일 작성
+func writeCookie(t *testing.T, path, user, password string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(user+":"+password+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestCoreBackend(t *testing.T, stub *coreRPCStub, cookieFile, wallet string) *bitcoinCoreBackend {
+	t.Helper()
+	fetcher := newHTTPFetcher(context.Background(), newHTTPClient(directDial))
+	backend, err := newBitcoinCoreBackend(stub.server.URL, "", "", cookieFile, wallet, fetcher)
+	if err != nil {
+		t.Fatalf("newBitcoinCoreBackend: %v", err)
+	}
+	return backend
+}
+
+// testRawTx 테스트용 직렬화 거래와 거래 ID
+func testRawTx(t *testing.T) (string, string) {
+	t.Helper()
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Index: 0}, Sequence: wire.MaxTxInSequenceNum})
+	tx.AddTxOut(&wire.TxOut{Value: 10_000, PkScript: []byte{0x00, 0x14, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}})
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return hex.EncodeToString(buf.Bytes()), tx.TxHash().String()
+}
+
+func TestBitcoinCoreCookieAuth(t *testing.T) {
+	stub := newCoreRPCStub(t, "__cookie__", "first", func(call coreRPCCall) (interface{}, *bitcoinCoreRPCError) {
+		return 850000, nil
+	})
+	cookie := filepath.Join(t.TempDir(), ".cookie")
+	writeCookie(t, cookie, "__cookie__", "first")
+	backend := newTestCoreBackend(t, stub, cookie, "")
+
+	if height, err := backend.TipHeight(); err != nil || height != 850000 {
+		t.Fatalf("TipHeight = %d, %v", height, err)
+	}
+
+	// 노드가 재시작되면 새 쿠키를 다시 읽어야 함
+	stub.setAuth("__cookie__", "second")
+	if _, err := backend.TipHeight(); err == nil || !strings.Contains(err.Error(), "인증 실패") {
+		t.Fatalf("TipHeight with stale cookie = %v, want authentication failure", err)
+	}
+	writeCookie(t, cookie, "__cookie__", "second")
+	if _, err := backend.TipHeight(); err != nil {
+		t.Fatalf("TipHeight after cookie rotation: %v", err)
+	}
+
+	os.Remove(cookie)
+	if _, err := backend.TipHeight(); err == nil || !strings.Contains(err.Error(), "쿠키 파일") {
+		t.Errorf("TipHeight without cookie file = %v, want cookie read error", err)
+	}
+}
+
+func TestBitcoinCoreScanUTXOs(t *testing.T) {
+	stub := newCoreRPCStub(t, "user", "pass", func(call coreRPCCall) (interface{}, *bitcoinCoreRPCError) {
+		if call.Method != "scantxoutset" {
+			return nil, &bitcoinCoreRPCError{Code: -32601, Message: "Method not found"}
+		}
+		var action string
+		var descriptors []string
+		json.Unmarshal(call.Params[0], &action)
+		json.Unmarshal(call.Params[1], &descriptors)
+		if action != "start" || len(descriptors) != 1 || descriptors[0] != "addr("+testWalletAddress+")" {
+			return nil, &bitcoinCoreRPCError{Code: -8, Message: "unexpected scan request"}
+		}
+		return map[string]interface{}{
+			"success": true,
+			"unspents": []map[string]interface{}{
+				{"txid": testTxID(1), "vout": 0, "amount": 0.0001, "height": 849000},
+				{"txid": testTxID(2), "vout": 3, "amount": 0.29, "height": 849500},
+			},
+		}, nil
+	})
+	cookie := filepath.Join(t.TempDir(), ".cookie")
+	writeCookie(t, cookie, "user", "pass")
+	backend := newTestCoreBackend(t, stub, cookie, "")
+
+	utxos, err := backend.AddressUTXOs(testWalletAddress)
+	if err != nil {
+		t.Fatalf("AddressUTXOs: %v", err)
+	}
+	if len(utxos) != 2 {
+		t.Fatalf("got %d UTXOs, want 2", len(utxos))
+	}
+	if utxos[0].Value != 10_000 || !utxos[0].Status.Confirmed || utxos[0].Status.BlockHeight != 849000 {
+		t.Errorf("first UTXO = %+v", utxos[0])
+	}
+	if utxos[1].Value != 29_000_000 || utxos[1].Vout != 3 || utxos[1].Status.BlockHeight != 849500 {
+		t.Errorf("second UTXO = %+v", utxos[1])
+	}
+	if got := strings.Join(stub.methods(), ","); got != "/ scantxoutset" {
+		t.Errorf("calls = %s, want a single scantxoutset on the node endpoint", got)
+	}
+}
+
+func TestBitcoinCoreWalletUTXOs(t *testing.T) {
+	stub := newCoreRPCStub(t, "user", "pass", func(call coreRPCCall) (interface{}, *bitcoinCoreRPCError) {
+		switch call.Method {
+		case "getaddressinfo":
+			return map[string]interface{}{"ismine": false, "iswatchonly": true}, nil
+		case "getblockcount":
+			return 850000, nil
+		case "listunspent":
+			return []map[string]interface{}{
+				{"txid": testTxID(1), "vout": 0, "amount": 0.0005, "confirmations": 10},
+				{"txid": testTxID(2), "vout": 1, "amount": 0.002, "confirmations": 0},
+			}, nil
+		}
+		return nil, &bitcoinCoreRPCError{Code: -32601, Message: "Method not found"}
+	})
+	cookie := filepath.Join(t.TempDir(), ".cookie")
+	writeCookie(t, cookie, "user", "pass")
+	backend := newTestCoreBackend(t, stub, cookie, "watch")
+
+	utxos, err := backend.AddressUTXOs(testWalletAddress)
+	if err != nil {
+		t.Fatalf("AddressUTXOs: %v", err)
+	}
+	if len(utxos) != 2 {
+		t.Fatalf("got %d UTXOs, want 2", len(utxos))
+	}
+	if utxos[0].Value != 50_000 || !utxos[0].Status.Confirmed || utxos[0].Status.BlockHeight != 849991 {
+		t.Errorf("confirmed UTXO = %+v, want height 849991 from 10 confirmations", utxos[0])
+	}
+	if utxos[1].Value != 200_000 || utxos[1].Status.Confirmed {
+		t.Errorf("mempool UTXO = %+v", utxos[1])
+	}
+
+	want := "/wallet/watch getaddressinfo,/ getblockcount,/wallet/watch listunspent"
+	if got := strings.Join(stub.methods(), ","); got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+}
+
+func TestBitcoinCoreRawTransactionFallback(t *testing.T) {
+	rawHex, txid := testRawTx(t)
+	stub := newCoreRPCStub(t, "user", "pass", func(call coreRPCCall) (interface{}, *bitcoinCoreRPCError) {
+		switch call.Method {
+		case "getrawtransaction":
+			// txindex 없는 노드는 지갑 밖의 확인된 거래를 찾지 못함
+			return nil, &bitcoinCoreRPCError{Code: -5, Message: "No such mempool or blockchain transaction"}
+		case "gettransaction":
+			if call.Path != "/wallet/watch" {
+				return nil, &bitcoinCoreRPCError{Code: -19, Message: "Wallet file not specified"}
+			}
+			return map[string]interface{}{"hex": rawHex, "blockhash": strings.Repeat("0", 64), "blocktime": 1700000000}, nil
+		}
+		return nil, &bitcoinCoreRPCError{Code: -32601, Message: "Method not found"}
+	})
+	cookie := filepath.Join(t.TempDir(), ".cookie")
+	writeCookie(t, cookie, "user", "pass")
+
+	backend := newTestCoreBackend(t, stub, cookie, "watch")
+	raw, err := backend.RawTransaction(txid)
+	if err != nil {
+		t.Fatalf("RawTransaction: %v", err)
+	}
+	if hex.EncodeToString(raw) != rawHex {
+		t.Errorf("RawTransaction = %x, want %s", raw, rawHex)
+	}
+	tx, result, err := backend.rawTransaction(txid)
+	if err != nil {
+		t.Fatalf("rawTransaction: %v", err)
+	}
+	if tx.TxHash().String() != txid || result.BlockTime != 1700000000 {
+		t.Errorf("rawTransaction = %s (blocktime %d), want %s", tx.TxHash(), result.BlockTime, txid)
+	}
+	want := "/ getrawtransaction,/wallet/watch gettransaction,/ getrawtransaction,/wallet/watch gettransaction"
+	if got := strings.Join(stub.methods(), ","); got != want {
+		t.Errorf("calls = %s, want %s", got, want)
+	}
+
+	// 지갑이 없으면 대체 조회 없이 원래 오류를 반환
+	noWallet := newTestCoreBackend(t, stub, cookie, "")
+	if _, err := noWallet.RawTransaction(txid); err == nil || !strings.Contains(err.Error(), "-5") {
+		t.Errorf("RawTransaction without wallet = %v, want the getrawtransaction error", err)
+	}
+}
+
+func TestBitcoinCoreAddressTransactionsIncludesSends(t *testing.T) {
+	walletScript := walletScriptFromState(&filterState{Address: testWalletAddress})
+	funding := testCoinbaseTx()
+	received := spendingTx(wire.OutPoint{Hash: funding.TxHash(), Index: 0}, 100_000, walletScript)
+	sent := spendingTx(wire.OutPoint{Hash: received.TxHash(), Index: 0}, 90_000, []byte{0x51})
+	// 같은 지갑의 다른 주소 거래
+	unrelated := spendingTx(wire.OutPoint{Hash: funding.TxHash(), Index: 0}, 50_000, []byte{0x51})
+
+	rawHex := make(map[string]string)
+	for _, tx := range []*wire.MsgTx{funding, received, sent, unrelated} {
+		rawHex[tx.TxHash().String()] = hex.EncodeToString(serializeTx(t, tx))
+	}
+
+	stub := newCoreRPCStub(t, "user", "pass", func(call coreRPCCall) (interface{}, *bitcoinCoreRPCError) {
+		switch call.Method {
+		case "listtransactions":
+			// 보내기 항목의 address는 받는 사람 주소, 같은 거래가 여러 항목으로 나올 수 있음
+			return []map[string]interface{}{
+				{"address": testWalletAddress, "category": "receive", "txid": received.TxHash().String()},
+				{"address": testRecipientAddress, "category": "send", "txid": sent.TxHash().String()},
+				{"address": testRecipientAddress, "category": "send", "txid": sent.TxHash().String()},
+				{"address": testRecipientAddress, "category": "receive", "txid": unrelated.TxHash().String()},
+			}, nil
+		case "getrawtransaction":
+			var txid string
+			json.Unmarshal(call.Params[0], &txid)
+			if raw, ok := rawHex[txid]; ok {
+				return map[string]interface{}{"hex": raw}, nil
+			}
+			return nil, &bitcoinCoreRPCError{Code: -5, Message: "No such mempool or blockchain transaction"}
+		}
+		return nil, &bitcoinCoreRPCError{Code: -32601, Message: "Method not found"}
+	})
+	cookie := filepath.Join(t.TempDir(), ".cookie")
+	writeCookie(t, cookie, "user", "pass")
+	backend := newTestCoreBackend(t, stub, cookie, "watch")
+
+	txs, err := backend.AddressTransactions(testWalletAddress, "")
+	if err != nil {
+		t.Fatalf("AddressTransactions: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, tx := range txs {
+		got[tx.TxID] = true
+	}
+	if len(txs) != 2 || !got[received.TxHash().String()] || !got[sent.TxHash().String()] {
+		t.Errorf("AddressTransactions returned %d txs %v, want the receive and the send once each without other wallet addresses", len(txs), got)
+	}
+}
+
+func TestBitcoinCoreTransactionStatus(t *testing.T) {
+	coinbase := testCoinbaseTx()
+	rawHex := hex.EncodeToString(serializeTx(t, coinbase))
+	blockHash := strings.Repeat("ab", 32)
+	headerDown := false
+	stub := newCoreRPCStub(t, "user", "pass", func(call coreRPCCall) (interface{}, *bitcoinCoreRPCError) {
+		switch call.Method {
+		case "getrawtransaction":
+			return map[string]interface{}{"hex": rawHex, "blockhash": blockHash}, nil
+		case "getblockheader":
+			if headerDown {
+				return nil, &bitcoinCoreRPCError{Code: -5, Message: "Block not found"}
+			}
+			return map[string]interface{}{"height": 849000, "time": 1700000000}, nil
+		}
+		return nil, &bitcoinCoreRPCError{Code: -32601, Message: "Method not found"}
+	})
+	cookie := filepath.Join(t.TempDir(), ".cookie")
+	writeCookie(t, cookie, "user", "pass")
+	backend := newTestCoreBackend(t, stub, cookie, "")
+
+	details, err := backend.Transaction(coinbase.TxHash().String())
+	if err != nil {
+		t.Fatalf("Transaction: %v", err)
+	}
+	if !details.Status.Confirmed || details.Status.BlockHeight != 849000 || details.Status.BlockHash != blockHash {
+		t.Errorf("status = %+v, want confirmed at 849000", details.Status)
+	}
+
+	// 블록 헤더를 조회하지 못해도 확인된 거래를 미확인으로 보고하지 않음
+	headerDown = true
+	if details, err := backend.Transaction(coinbase.TxHash().String()); err == nil {
+		t.Errorf("Transaction with failing getblockheader = %+v, want an error", details.Status)
+	}
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// utxoCacheTTL 주소별 UTXO 목록 캐시 유효 시간 (잔액 조회와 전송 준비 사이의 중복 요청 방지)
+	utxoCacheTTL = 15 * time.Second
+
+	// unconfirmedTxCacheTTL 미확인 거래 캐시 유효 시간 (확인된 거래는 바뀌지 않으므로 계속 보관)
+	unconfirmedTxCacheTTL = 30 * time.Second
+
+	// txCacheLimit 거래 캐시 최대 항목 수
+	txCacheLimit = 1000
+
+	// prevoutFetchConcurrency 이전 거래 동시 조회 수 (공용 서버 요청 한도 고려)
+	prevoutFetchConcurrency = 4
+)
+
+// txCacheEntry 거래 캐시 항목
+type txCacheEntry struct {
+	details   *TxDetails
+	expiresAt time.Time // 확인된 거래는 zero value (만료 없음)
+}
+
+// utxoCacheEntry 주소별 UTXO 캐시 항목
+type utxoCacheEntry struct {
+	utxos     []UTXO
+	expiresAt time.Time
+}
+
+// inflightCall 진행 중인 요청 (같은 키의 동시 요청은 결과를 공유)
+type inflightCall struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// cachedBackend 거래와 UTXO 조회 결과를 캐시하고 동시 중복 요청을 하나로 합치는 백엔드
+// 나머지 조회(주소 통계, 수수료율, 블록 높이)는 내부 백엔드를 그대로 사용
+type cachedBackend struct {
+	ChainBackend
+
+	mu       sync.Mutex
+	txs      map[string]txCacheEntry
+	raws     map[string][]byte // 원본 거래 (txid가 일치하는 것만 보관하므로 만료 없음)
+	utxos    map[string]utxoCacheEntry
+	inflight map[string]*inflightCall
+}
+
+// newCachedBackend 캐시 백엔드 생성
+func newCachedBackend(backend ChainBackend) *cachedBackend {
+	return &cachedBackend{
+		ChainBackend: backend,
+		txs:          make(map[string]txCacheEntry),
+		raws:         make(map[string][]byte),
+		utxos:        make(map[string]utxoCacheEntry),
+		inflight:     make(map[string]*inflightCall),
+	}
+}
+
+// do 같은 키로 진행 중인 요청이 있으면 그 결과를 기다리고, 없으면 fn 실행
+func (c *cachedBackend) do(key string, fn func() (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	if call, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		<-call.done
+		return call.value, call.err
+	}
+	call := &inflightCall{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mu.Unlock()
+
+	call.value, call.err = fn()
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	c.mu.Unlock()
+	close(call.done)
+
+	return call.value, call.err
+}
+
+// AddressUTXOs 주소의 모든 UTXO 조회 (짧은 시간 캐시)
+func (c *cachedBackend) AddressUTXOs(address string) ([]UTXO, error) {
+	c.mu.Lock()
+	entry, ok := c.utxos[address]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return append([]UTXO(nil), entry.utxos...), nil
+	}
+
+	value, err := c.do("utxo:"+address, func() (interface{}, error) {
+		utxos, err := c.ChainBackend.AddressUTXOs(address)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.utxos[address] = utxoCacheEntry{utxos: utxos, expiresAt: time.Now().Add(utxoCacheTTL)}
+		c.mu.Unlock()
+		return utxos, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]UTXO(nil), value.([]UTXO)...), nil
+}
+
+// Transaction 거래 세부정보 조회 (확인된 거래는 만료 없이 캐시)
+func (c *cachedBackend) Transaction(txid string) (*TxDetails, error) {
+	c.mu.Lock()
+	entry, ok := c.txs[txid]
+	c.mu.Unlock()
+	if ok && (entry.expiresAt.IsZero() || time.Now().Before(entry.expiresAt)) {
+		return entry.details, nil
+	}
+
+	value, err := c.do("tx:"+txid, func() (interface{}, error) {
+		details, err := c.ChainBackend.Transaction(txid)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := txCacheEntry{details: details}
+		if !details.Status.Confirmed {
+			entry.expiresAt = time.Now().Add(unconfirmedTxCacheTTL)
+		}
+
+		c.mu.Lock()
+		if len(c.txs) >= txCacheLimit {
+			// 가득 차면 임의의 항목 하나 제거
+			for key := range c.txs {
+				delete(c.txs, key)
+				break
+			}
+		}
+		c.txs[txid] = entry
+		c.mu.Unlock()
+		return details, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.(*TxDetails), nil
+}
+
+// RawTransaction 직렬화된 원본 거래 조회 (캐시)
+func (c *cachedBackend) RawTransaction(txid string) ([]byte, error) {
+	c.mu.Lock()
+	raw, ok := c.raws[txid]
+	c.mu.Unlock()
+	if ok {
+		return raw, nil
+	}
+
+	value, err := c.do("raw:"+txid, func() (interface{}, error) {
+		raw, err := c.ChainBackend.RawTransaction(txid)
+		if err != nil {
+			return nil, err
+		}
+
+		// txid가 다른 데이터는 캐시하지 않음 (검증은 호출하는 쪽에서 다시 수행)
+		if _, computed, err := parseRawTransaction(raw); err != nil || computed != txid {
+			return raw, nil
+		}
+
+		c.mu.Lock()
+		if len(c.raws) >= txCacheLimit {
+			for key := range c.raws {
+				delete(c.raws, key)
+				break
+			}
+		}
+		c.raws[txid] = raw
+		c.mu.Unlock()
+		return raw, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return value.([]byte), nil
+}
+
+// Broadcast 거래 브로드캐스트 (성공하면 UTXO 캐시 무효화)
+func (c *cachedBackend) Broadcast(txHex string) (string, error) {
+	txid, err := c.ChainBackend.Broadcast(txHex)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	c.utxos = make(map[string]utxoCacheEntry)
+	c.mu.Unlock()
+
+	return txid, nil
+}
+
+// fetchRawTransactions 여러 원본 거래를 동시에 조회 (중복 제거, 동시 요청 수 제한)
+func (a *App) fetchRawTransactions(txids []string) (map[string][]byte, error) {
+	return fetchConcurrently(txids, a.chain().RawTransaction)
+}
+
+// fetchConcurrently 키별 조회를 최대 prevoutFetchConcurrency개씩 동시에 실행
+func fetchConcurrently[T any](keys []string, fetch func(string) (T, error)) (map[string]T, error) {
+	unique := make([]string, 0, len(keys))
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, key)
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+	results := make(map[string]T, len(unique))
+	sem := make(chan struct{}, prevoutFetchConcurrency)
+
+	for _, key := range unique {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(key string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			value, err := fetch(key)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			results[key] = value
+		}(key)
+	}
+	wg.Wait()
+
+	return results, firstErr
+}
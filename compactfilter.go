@@ -0,0 +1,702 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/gcs"
+	"github.com/btcsuite/btcd/btcutil/gcs/builder"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	// filterStateDirName 압축 블록 필터 스캔 결과를 주소별 파일로 저장하는 폴더 이름 (설정 파일과 같은 폴더)
+	filterStateDirName = "wallet-filters"
+
+	// segwitActivationHeight 메인넷 세그윗 활성화 높이 (P2WPKH 주소는 이전 블록에 나타날 수 없음)
+	segwitActivationHeight = 481824
+
+	// filterHeaderBatch REST /headers 한 번에 받을 헤더 수 (Bitcoin Core 최대값)
+	filterHeaderBatch = 2000
+
+	// filterReorgDepth 체인 재구성 감지를 위해 보관하는 최근 블록 해시 수
+	filterReorgDepth = 100
+)
+
+// filterTx 지갑과 관련된 거래 (블록 필터로 찾았거나 직접 브로드캐스트함)
+type filterTx struct {
+	Hex       string `json:"hex"`
+	Height    int64  `json:"height"` // 0이면 미확인
+	BlockHash string `json:"blockHash"`
+	BlockTime int64  `json:"blockTime"`
+}
+
+// filterState 주소별 필터 스캔 상태 (파일로 저장하여 재시작 시 이어서 스캔)
+type filterState struct {
+	Address string                 `json:"address"`
+	Height  int64                  `json:"height"` // 스캔을 마친 마지막 높이
+	Recent  map[int64]string       `json:"recent"` // 최근 블록 해시 (체인 재구성 감지용)
+	Txs     map[string]filterTx    `json:"txs"`    // 지갑 관련 거래
+	scanned map[string]*wire.MsgTx // 파싱한 지갑 관련 거래 (저장하지 않음)
+}
+
+// compactFilterBackend BIP157/158 압축 블록 필터 백엔드
+// Bitcoin Core REST 인터페이스(-rest -blockfilterindex)에서 필터를 받아 지갑 스크립트를 로컬에서 비교하고,
+// 일치하는 블록만 통째로 내려받으므로 서버는 어떤 주소가 지갑의 것인지 알 수 없음
+type compactFilterBackend struct {
+	restURL  string
+	birthday int64
+	fetcher  *httpFetcher
+	node     *bitcoinCoreBackend // 브로드캐스트와 수수료율 조회용 RPC (인증 정보가 없으면 nil)
+
+	mu     sync.Mutex
+	states map[string]*filterState // 주소별 스캔 상태
+}
+
+// newCompactFilterBackend 압축 블록 필터 백엔드 생성 (restURL 예: http://127.0.0.1:8332)
+func newCompactFilterBackend(restURL string, birthday int64, node *bitcoinCoreBackend, fetcher *httpFetcher) (*compactFilterBackend, error) {
+	parsed, err := url.Parse(restURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("잘못된 블록 필터 서버 주소: %s", restURL)
+	}
+	if birthday < segwitActivationHeight {
+		birthday = segwitActivationHeight
+	}
+
+	return &compactFilterBackend{
+		restURL:  strings.TrimRight(restURL, "/"),
+		birthday: birthday,
+		fetcher:  fetcher,
+		node:     node,
+	}, nil
+}
+
+// filterStatePath 주소의 필터 스캔 상태 파일 경로 (파일 이름은 주소의 SHA-256 해시)
+func filterStatePath(address string) string {
+	sum := sha256.Sum256([]byte(address))
+	return filepath.Join(filepath.Dir(configFilePath()), filterStateDirName, hex.EncodeToString(sum[:])+".json")
+}
+
+// Name 백엔드 이름
+func (f *compactFilterBackend) Name() string {
+	return "compactfilter(" + f.restURL + ")"
+}
+
+// get REST 요청 후 응답 본문 반환
+func (f *compactFilterBackend) get(path string) ([]byte, error) {
+	status, body, err := f.fetcher.do(httpRequest{
+		method:     http.MethodGet,
+		url:        f.restURL + "/rest" + path,
+		idempotent: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if status != 200 {
+		return nil, statusError(status, body)
+	}
+	return body, nil
+}
+
+// getHex hex 형식 REST 응답을 디코딩하여 반환
+func (f *compactFilterBackend) getHex(path string) ([]byte, error) {
+	body, err := f.get(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := hex.DecodeString(strings.TrimSpace(string(body)))
+	if err != nil {
+		return nil, fmt.Errorf("hex 디코딩 실패: %v", err)
+	}
+	return data, nil
+}
+
+// blockHash 높이에 해당하는 블록 해시 조회
+func (f *compactFilterBackend) blockHash(height int64) (*chainhash.Hash, error) {
+	body, err := f.get(fmt.Sprintf("/blockhashbyheight/%d.hex", height))
+	if err != nil {
+		return nil, fmt.Errorf("블록 해시 조회 실패: %w", err)
+	}
+	return chainhash.NewHashFromStr(strings.TrimSpace(string(body)))
+}
+
+// blockHashes start 블록부터 최대 filterHeaderBatch개의 블록 해시를 헤더로 계산
+func (f *compactFilterBackend) blockHashes(start *chainhash.Hash) ([]chainhash.Hash, error) {
+	body, err := f.get(fmt.Sprintf("/headers/%d/%s.bin", filterHeaderBatch, start))
+	if err != nil {
+		return nil, fmt.Errorf("블록 헤더 조회 실패: %w", err)
+	}
+	if len(body)%wire.MaxBlockHeaderPayload != 0 {
+		return nil, fmt.Errorf("블록 헤더 길이가 올바르지 않습니다")
+	}
+
+	hashes := make([]chainhash.Hash, 0, len(body)/wire.MaxBlockHeaderPayload)
+	reader := bytes.NewReader(body)
+	for reader.Len() > 0 {
+		var header wire.BlockHeader
+		if err := header.Deserialize(reader); err != nil {
+			return nil, fmt.Errorf("블록 헤더 파싱 실패: %v", err)
+		}
+		hashes = append(hashes, header.BlockHash())
+	}
+	return hashes, nil
+}
+
+// matchBlock 블록 필터에 지갑 스크립트가 들어 있는지 확인 (거짓 양성 가능, 거짓 음성 없음)
+func (f *compactFilterBackend) matchBlock(hash *chainhash.Hash, script []byte) (bool, error) {
+	data, err := f.getHex(fmt.Sprintf("/blockfilter/basic/%s.hex", hash))
+	if err != nil {
+		return false, fmt.Errorf("블록 필터 조회 실패: %w", err)
+	}
+
+	filter, err := gcs.FromNBytes(builder.DefaultP, builder.DefaultM, data)
+	if err != nil {
+		return false, fmt.Errorf("블록 필터 파싱 실패: %v", err)
+	}
+	if filter.N() == 0 {
+		return false, nil
+	}
+	return filter.Match(builder.DeriveKey(hash), script)
+}
+
+// block 블록 전체 조회
+func (f *compactFilterBackend) block(hash *chainhash.Hash) (*wire.MsgBlock, error) {
+	body, err := f.get(fmt.Sprintf("/block/%s.bin", hash))
+	if err != nil {
+		return nil, fmt.Errorf("블록 조회 실패: %w", err)
+	}
+
+	var block wire.MsgBlock
+	if err := block.Deserialize(bytes.NewReader(body)); err != nil {
+		return nil, fmt.Errorf("블록 파싱 실패: %v", err)
+	}
+	if block.BlockHash() != *hash {
+		return nil, fmt.Errorf("블록 해시가 요청과 다릅니다: %s", hash)
+	}
+	return &block, nil
+}
+
+// chainTip 노드의 최신 블록 높이
+func (f *compactFilterBackend) chainTip() (int64, error) {
+	body, err := f.get("/chaininfo.json")
+	if err != nil {
+		return 0, fmt.Errorf("블록 높이 조회 실패: %w", err)
+	}
+
+	var info struct {
+		Blocks int64 `json:"blocks"`
+	}
+	if err := json.Unmarshal(body, &info); err != nil {
+		return 0, fmt.Errorf("블록 높이 파싱 실패: %v", err)
+	}
+	return info.Blocks, nil
+}
+
+// loadState 주소의 스캔 상태를 불러옴 (저장된 상태가 없으면 birthday부터 새로 시작)
+func (f *compactFilterBackend) loadState(address string) *filterState {
+	if state, ok := f.states[address]; ok {
+		return state
+	}
+
+	state := &filterState{}
+	if data, err := os.ReadFile(filterStatePath(address)); err == nil {
+		if json.Unmarshal(data, state) != nil || state.Address != address {
+			state = &filterState{}
+		}
+	}
+	if state.Address == "" {
+		state.Address = address
+		state.Height = f.birthday - 1
+	}
+	if state.Recent == nil {
+		state.Recent = make(map[int64]string)
+	}
+	if state.Txs == nil {
+		state.Txs = make(map[string]filterTx)
+	}
+
+	state.scanned = make(map[string]*wire.MsgTx, len(state.Txs))
+	for txid, entry := range state.Txs {
+		if entry.Height <= 0 {
+			delete(state.Txs, txid)
+			continue
+		}
+		raw, err := hex.DecodeString(entry.Hex)
+		if err != nil {
+			continue
+		}
+		if tx, computed, err := parseRawTransaction(raw); err == nil && computed == txid {
+			state.scanned[txid] = tx
+		}
+	}
+
+	if f.states == nil {
+		f.states = make(map[string]*filterState)
+	}
+	f.states[address] = state
+	return state
+}
+
+// saveState 스캔 상태를 파일에 저장 (직접 브로드캐스트한 미확인 거래는 메모리에만 둠)
+func (f *compactFilterBackend) saveState(state *filterState) error {
+	persisted := *state
+	persisted.Txs = make(map[string]filterTx, len(state.Txs))
+	for txid, entry := range state.Txs {
+		if entry.Height > 0 {
+			persisted.Txs[txid] = entry
+		}
+	}
+
+	data, err := json.Marshal(&persisted)
+	if err != nil {
+		return err
+	}
+	path := filterStatePath(state.Address)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("필터 스캔 상태 저장 실패: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("필터 스캔 상태 저장 실패: %v", err)
+	}
+	return nil
+}
+
+// rewind 체인 재구성이 있으면 공통 조상까지 되돌림 (그 이후의 지갑 거래는 제거)
+func (f *compactFilterBackend) rewind(state *filterState) error {
+	for state.Height >= f.birthday {
+		known, ok := state.Recent[state.Height]
+		if !ok {
+			return nil
+		}
+		hash, err := f.blockHash(state.Height)
+		if err != nil {
+			return err
+		}
+		if hash.String() == known {
+			return nil
+		}
+
+		delete(state.Recent, state.Height)
+		for txid, entry := range state.Txs {
+			if entry.Height >= state.Height {
+				delete(state.Txs, txid)
+				delete(state.scanned, txid)
+			}
+		}
+		state.Height--
+	}
+	return nil
+}
+
+// sync 마지막 스캔 높이부터 최신 블록까지 필터를 비교하고 일치하는 블록에서 지갑 거래를 찾음
+func (f *compactFilterBackend) sync(address string) (*filterState, error) {
+	addr, err := btcutil.DecodeAddress(address, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, fmt.Errorf("주소 파싱 실패: %v", err)
+	}
+	script, err := txscript.PayToAddrScript(addr)
+	if err != nil {
+		return nil, fmt.Errorf("스크립트 생성 실패: %v", err)
+	}
+
+	state := f.loadState(address)
+	if err := f.rewind(state); err != nil {
+		return nil, err
+	}
+
+	tip, err := f.chainTip()
+	if err != nil {
+		return nil, err
+	}
+
+	for state.Height < tip {
+		start, err := f.blockHash(state.Height + 1)
+		if err != nil {
+			return nil, err
+		}
+		hashes, err := f.blockHashes(start)
+		if err != nil {
+			return nil, err
+		}
+		if len(hashes) == 0 {
+			break
+		}
+
+		for i := range hashes {
+			hash := &hashes[i]
+			height := state.Height + 1
+
+			matched, err := f.matchBlock(hash, script)
+			if err != nil {
+				return nil, err
+			}
+			if matched {
+				block, err := f.block(hash)
+				if err != nil {
+					return nil, err
+				}
+				f.connectBlock(state, block, height, script)
+			}
+
+			state.Height = height
+			state.Recent[height] = hash.String()
+			delete(state.Recent, height-filterReorgDepth)
+		}
+
+		// 배치마다 저장하여 중단되어도 이어서 스캔
+		if err := f.saveState(state); err != nil {
+			return nil, err
+		}
+	}
+
+	return state, nil
+}
+
+// connectBlock 블록에서 지갑 스크립트로 보내는 출력이나 지갑 UTXO를 쓰는 입력이 있는 거래를 기록
+// 미확인 거래와 같은 입력을 쓰는 다른 거래가 확인되면 그 미확인 거래(와 하위 거래)는 제거
+func (f *compactFilterBackend) connectBlock(state *filterState, block *wire.MsgBlock, height int64, script []byte) {
+	blockHash := block.BlockHash().String()
+	pending := pendingSpends(state)
+	for _, tx := range block.Transactions {
+		txid := tx.TxHash().String()
+		for _, txIn := range tx.TxIn {
+			if spender, ok := pending[txIn.PreviousOutPoint]; ok && spender != txid {
+				dropUnconfirmed(state, spender)
+			}
+		}
+
+		if !relevantTx(state, tx, script) {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := tx.Serialize(&buf); err != nil {
+			continue
+		}
+		state.Txs[txid] = filterTx{
+			Hex:       hex.EncodeToString(buf.Bytes()),
+			Height:    height,
+			BlockHash: blockHash,
+			BlockTime: block.Header.Timestamp.Unix(),
+		}
+		state.scanned[txid] = tx
+	}
+}
+
+// relevantTx 거래가 지갑 스크립트로 보내거나 지갑 출력을 쓰는지 확인
+func relevantTx(state *filterState, tx *wire.MsgTx, script []byte) bool {
+	for _, txOut := range tx.TxOut {
+		if bytes.Equal(txOut.PkScript, script) {
+			return true
+		}
+	}
+	for _, txIn := range tx.TxIn {
+		if prevTx, ok := state.scanned[txIn.PreviousOutPoint.Hash.String()]; ok &&
+			int(txIn.PreviousOutPoint.Index) < len(prevTx.TxOut) &&
+			bytes.Equal(prevTx.TxOut[txIn.PreviousOutPoint.Index].PkScript, script) {
+			return true
+		}
+	}
+	return false
+}
+
+// pendingSpends 미확인 지갑 거래가 쓰는 outpoint와 그 거래 ID
+func pendingSpends(state *filterState) map[wire.OutPoint]string {
+	spends := make(map[wire.OutPoint]string)
+	for txid, entry := range state.Txs {
+		tx, ok := state.scanned[txid]
+		if entry.Height > 0 || !ok {
+			continue
+		}
+		for _, txIn := range tx.TxIn {
+			spends[txIn.PreviousOutPoint] = txid
+		}
+	}
+	return spends
+}
+
+// dropUnconfirmed 충돌로 무효가 된 미확인 거래와 그 출력을 쓰는 미확인 하위 거래 제거
+func dropUnconfirmed(state *filterState, txid string) {
+	if entry, ok := state.Txs[txid]; !ok || entry.Height > 0 {
+		return
+	}
+	delete(state.Txs, txid)
+	delete(state.scanned, txid)
+
+	for childID, child := range state.scanned {
+		for _, txIn := range child.TxIn {
+			if txIn.PreviousOutPoint.Hash.String() == txid {
+				dropUnconfirmed(state, childID)
+				break
+			}
+		}
+	}
+}
+
+// txStatus 기록된 거래의 확인 상태
+func (entry filterTx) txStatus() TxStatus {
+	if entry.Height == 0 {
+		return TxStatus{}
+	}
+	return TxStatus{
+		Confirmed:   true,
+		BlockHeight: entry.Height,
+		BlockHash:   entry.BlockHash,
+		BlockTime:   entry.BlockTime,
+	}
+}
+
+// walletOutputs 지갑 스크립트로 받은 모든 출력과 사용 여부
+func walletOutputs(state *filterState) (outputs []UTXO, spent map[wire.OutPoint]bool) {
+	spent = make(map[wire.OutPoint]bool)
+	for _, tx := range state.scanned {
+		for _, txIn := range tx.TxIn {
+			spent[txIn.PreviousOutPoint] = true
+		}
+	}
+
+	script := walletScriptFromState(state)
+	for txid, tx := range state.scanned {
+		for vout, txOut := range tx.TxOut {
+			if bytes.Equal(txOut.PkScript, script) {
+				outputs = append(outputs, UTXO{
+					TxID:   txid,
+					Vout:   vout,
+					Value:  txOut.Value,
+					Status: state.Txs[txid].txStatus(),
+				})
+			}
+		}
+	}
+	return outputs, spent
+}
+
+// walletScriptFromState 스캔 상태 주소의 출력 스크립트
+func walletScriptFromState(state *filterState) []byte {
+	addr, err := btcutil.DecodeAddress(state.Address, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil
+	}
+	script, _ := txscript.PayToAddrScript(addr)
+	return script
+}
+
+// outPoint UTXO의 outpoint
+func (u UTXO) outPoint() wire.OutPoint {
+	hash, _ := chainhash.NewHashFromStr(u.TxID)
+	return wire.OutPoint{Hash: *hash, Index: uint32(u.Vout)}
+}
+
+// AddressUTXOs 주소의 모든 UTXO 조회 (필터 스캔 후 로컬 계산)
+func (f *compactFilterBackend) AddressUTXOs(address string) ([]UTXO, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	state, err := f.sync(address)
+	if err != nil {
+		return nil, fmt.Errorf("블록 필터 스캔 실패: %w", err)
+	}
+
+	outputs, spent := walletOutputs(state)
+	utxos := make([]UTXO, 0, len(outputs))
+	for _, output := range outputs {
+		if !spent[output.outPoint()] {
+			utxos = append(utxos, output)
+		}
+	}
+	return utxos, nil
+}
+
+// AddressStats 주소 통계 조회 (필터 스캔 후 로컬 계산)
+func (f *compactFilterBackend) AddressStats(address string) (*AddressStats, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	state, err := f.sync(address)
+	if err != nil {
+		return nil, fmt.Errorf("주소 통계 조회 실패: %w", err)
+	}
+
+	stats := &AddressStats{}
+	outputs, spent := walletOutputs(state)
+	for _, output := range outputs {
+		if output.Status.Confirmed {
+			stats.ChainStats.FundedTxoCount++
+			stats.ChainStats.FundedTxoSum += output.Value
+		} else {
+			stats.MempoolStats.FundedTxoCount++
+			stats.MempoolStats.FundedTxoSum += output.Value
+		}
+		if spent[output.outPoint()] {
+			stats.ChainStats.SpentTxoCount++
+			stats.ChainStats.SpentTxoSum += output.Value
+		}
+	}
+	for _, entry := range state.Txs {
+		if entry.Height > 0 {
+			stats.ChainStats.TxCount++
+		} else {
+			stats.MempoolStats.TxCount++
+		}
+	}
+
+	return stats, nil
+}
+
+// AddressTransactions 주소의 거래 내역 조회 (필터 스캔 후 로컬 계산, 한 페이지)
+func (f *compactFilterBackend) AddressTransactions(address, lastSeenTxID string) ([]TxDetails, error) {
+	if lastSeenTxID != "" {
+		return nil, nil
+	}
+
+	f.mu.Lock()
+	state, err := f.sync(address)
+	var txids []string
+	if err == nil {
+		for txid := range state.Txs {
+			txids = append(txids, txid)
+		}
+	}
+	f.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("블록 필터 스캔 실패: %w", err)
+	}
+
+	return transactionsByID(txids, f.Transaction)
+}
+
+// rawTransaction 원시 거래 조회 (스캔한 지갑 거래 우선, 없으면 노드의 txindex 사용)
+func (f *compactFilterBackend) rawTransaction(txid string) (*wire.MsgTx, *filterTx, error) {
+	f.mu.Lock()
+	for _, state := range f.states {
+		if tx, ok := state.scanned[txid]; ok {
+			entry := state.Txs[txid]
+			f.mu.Unlock()
+			return tx, &entry, nil
+		}
+	}
+	f.mu.Unlock()
+
+	body, err := f.get(fmt.Sprintf("/tx/%s.bin", txid))
+	if err != nil {
+		return nil, nil, fmt.Errorf("원본 거래 조회 실패 (노드에 -txindex 필요): %w", err)
+	}
+	tx, computed, err := parseRawTransaction(body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if computed != txid {
+		return nil, nil, fmt.Errorf("거래 ID가 요청과 다릅니다: %s", computed)
+	}
+	return tx, nil, nil
+}
+
+// RawTransaction 직렬화된 원본 거래 조회
+func (f *compactFilterBackend) RawTransaction(txid string) ([]byte, error) {
+	tx, _, err := f.rawTransaction(txid)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Transaction 거래 세부정보 조회 (이전 출력은 스캔한 지갑 거래 또는 노드에서 조회)
+func (f *compactFilterBackend) Transaction(txid string) (*TxDetails, error) {
+	tx, entry, err := f.rawTransaction(txid)
+	if err != nil {
+		return nil, fmt.Errorf("거래 세부정보 조회 실패: %w", err)
+	}
+
+	details := txDetailsFromMsgTx(tx)
+	if entry != nil {
+		details.Status = entry.txStatus()
+	}
+
+	var totalInput, totalOutput int64
+	complete := true
+	for i, txIn := range tx.TxIn {
+		if blockchainIsCoinbase(txIn) {
+			complete = false
+			continue
+		}
+		prevTx, _, err := f.rawTransaction(txIn.PreviousOutPoint.Hash.String())
+		if err != nil || int(txIn.PreviousOutPoint.Index) >= len(prevTx.TxOut) {
+			complete = false
+			continue
+		}
+		prevOut := txOutputFromWire(prevTx.TxOut[txIn.PreviousOutPoint.Index])
+		details.Vin[i].Prevout = &prevOut
+		totalInput += prevOut.Value
+	}
+	for _, txOut := range tx.TxOut {
+		totalOutput += txOut.Value
+	}
+	if complete {
+		details.Fee = totalInput - totalOutput
+	}
+
+	return details, nil
+}
+
+// Broadcast 거래 브로드캐스트 (노드 RPC 사용) 후 관련 주소의 미확인 지갑 거래로 기록
+func (f *compactFilterBackend) Broadcast(txHex string) (string, error) {
+	if f.node == nil {
+		return "", fmt.Errorf("블록 필터 백엔드에서 브로드캐스트하려면 RPC 인증 정보가 필요합니다")
+	}
+
+	txid, err := f.node.Broadcast(txHex)
+	if err != nil {
+		return "", err
+	}
+
+	raw, err := hex.DecodeString(txHex)
+	if err != nil {
+		return txid, nil
+	}
+	tx, computed, err := parseRawTransaction(raw)
+	if err != nil {
+		return txid, nil
+	}
+
+	f.mu.Lock()
+	for _, state := range f.states {
+		if _, ok := state.Txs[computed]; !ok && relevantTx(state, tx, walletScriptFromState(state)) {
+			state.Txs[computed] = filterTx{Hex: txHex}
+			state.scanned[computed] = tx
+		}
+	}
+	f.mu.Unlock()
+
+	return txid, nil
+}
+
+// FeeEstimates 확인 목표 블록 수별 예상 수수료율 조회 (노드 RPC 사용)
+func (f *compactFilterBackend) FeeEstimates() (map[int]float64, error) {
+	if f.node == nil {
+		return nil, fmt.Errorf("블록 필터 백엔드에서 수수료율을 조회하려면 RPC 인증 정보가 필요합니다")
+	}
+	return f.node.FeeEstimates()
+}
+
+// TipHeight 최신 블록 높이 조회
+func (f *compactFilterBackend) TipHeight() (int64, error) {
+	return f.chainTip()
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// testFilterState 지갑 거래를 기록한 필터 스캔 상태 (height 0이면 미확인)
+func testFilterState(t *testing.T, txs map[*wire.MsgTx]int64) *filterState {
+	t.Helper()
+	state := &filterState{
+		Address: testWalletAddress,
+		Recent:  make(map[int64]string),
+		Txs:     make(map[string]filterTx),
+		scanned: make(map[string]*wire.MsgTx),
+	}
+	for tx, height := range txs {
+		var buf bytes.Buffer
+		if err := tx.Serialize(&buf); err != nil {
+			t.Fatal(err)
+		}
+		txid := tx.TxHash().String()
+		state.Txs[txid] = filterTx{Hex: hex.EncodeToString(buf.Bytes()), Height: height}
+		state.scanned[txid] = tx
+	}
+	return state
+}
+
+// spendingTx outpoint를 쓰고 script로 value를 보내는 거래
+func spendingTx(prev wire.OutPoint, value int64, script []byte) *wire.MsgTx {
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{PreviousOutPoint: prev, Sequence: wire.MaxTxInSequenceNum - 2})
+	tx.AddTxOut(&wire.TxOut{Value: value, PkScript: script})
+	return tx
+}
+
+func TestConnectBlockDropsConflictingBroadcast(t *testing.T) {
+	script := walletScriptFromState(&filterState{Address: testWalletAddress})
+	other := []byte{0x51}
+
+	funding := spendingTx(wire.OutPoint{Hash: chainhash.Hash{1}}, 100_000, script)
+	fundingOut := wire.OutPoint{Hash: funding.TxHash(), Index: 0}
+	broadcast := spendingTx(fundingOut, 90_000, script)
+	child := spendingTx(wire.OutPoint{Hash: broadcast.TxHash(), Index: 0}, 80_000, other)
+	unrelated := spendingTx(wire.OutPoint{Hash: chainhash.Hash{2}}, 50_000, script)
+
+	state := testFilterState(t, map[*wire.MsgTx]int64{funding: 100, broadcast: 0, child: 0, unrelated: 0})
+
+	// 수수료를 올린 대체 거래가 같은 UTXO를 쓰며 확인됨
+	replacement := spendingTx(fundingOut, 85_000, other)
+	block := wire.NewMsgBlock(&wire.BlockHeader{})
+	block.AddTransaction(replacement)
+	(&compactFilterBackend{}).connectBlock(state, block, 101, script)
+
+	for name, tx := range map[string]*wire.MsgTx{"broadcast": broadcast, "child": child} {
+		if _, ok := state.Txs[tx.TxHash().String()]; ok {
+			t.Errorf("%s transaction conflicting with a confirmed spend was kept", name)
+		}
+		if _, ok := state.scanned[tx.TxHash().String()]; ok {
+			t.Errorf("%s transaction is still in the scanned set", name)
+		}
+	}
+	if entry := state.Txs[replacement.TxHash().String()]; entry.Height != 101 {
+		t.Errorf("replacement entry = %+v, want confirmed at 101", entry)
+	}
+	if _, ok := state.Txs[unrelated.TxHash().String()]; !ok {
+		t.Error("unconfirmed transaction without a conflict was dropped")
+	}
+
+	outputs, spent := walletOutputs(state)
+	for _, output := range outputs {
+		if output.TxID == funding.TxHash().String() && !spent[output.outPoint()] {
+			t.Error("funding output should be spent by the confirmed replacement")
+		}
+	}
+}
+
+// restoreFilterState 테스트가 끝나면 상태 파일을 원래대로 되돌림
+func restoreFilterState(t *testing.T, path string) {
+	previous, readErr := os.ReadFile(path)
+	t.Cleanup(func() {
+		if readErr == nil {
+			os.WriteFile(path, previous, 0600)
+		} else {
+			os.Remove(path)
+		}
+	})
+}
+
+func TestSaveStateSkipsUnconfirmed(t *testing.T) {
+	script := walletScriptFromState(&filterState{Address: testWalletAddress})
+	confirmed := spendingTx(wire.OutPoint{Hash: chainhash.Hash{1}}, 100_000, script)
+	broadcast := spendingTx(wire.OutPoint{Hash: confirmed.TxHash(), Index: 0}, 90_000, script)
+	state := testFilterState(t, map[*wire.MsgTx]int64{confirmed: 100, broadcast: 0})
+
+	path := filterStatePath(testWalletAddress)
+	restoreFilterState(t, path)
+
+	if err := (&compactFilterBackend{}).saveState(state); err != nil {
+		t.Fatalf("saveState: %v", err)
+	}
+	if _, ok := state.Txs[broadcast.TxHash().String()]; !ok {
+		t.Error("saveState removed the unconfirmed transaction from memory")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved filterState
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := saved.Txs[broadcast.TxHash().String()]; ok {
+		t.Error("unconfirmed broadcast was written to the state file")
+	}
+	if _, ok := saved.Txs[confirmed.TxHash().String()]; !ok {
+		t.Error("confirmed transaction missing from the state file")
+	}
+}
+
+func TestFilterStatePerAddress(t *testing.T) {
+	other := testRecipientAddress
+	restoreFilterState(t, filterStatePath(testWalletAddress))
+	restoreFilterState(t, filterStatePath(other))
+	if filterStatePath(testWalletAddress) == filterStatePath(other) {
+		t.Fatal("different addresses share a state file")
+	}
+
+	backend := &compactFilterBackend{birthday: segwitActivationHeight}
+	first := backend.loadState(testWalletAddress)
+	first.Height = 850000
+	if err := backend.saveState(first); err != nil {
+		t.Fatalf("saveState: %v", err)
+	}
+
+	// 다른 주소를 조회해도 첫 주소의 스캔 진행 상황은 유지됨
+	second := backend.loadState(other)
+	if second.Height != segwitActivationHeight-1 {
+		t.Errorf("new address starts at %d, want %d", second.Height, segwitActivationHeight-1)
+	}
+	second.Height = 840000
+	if err := backend.saveState(second); err != nil {
+		t.Fatalf("saveState: %v", err)
+	}
+	if got := backend.loadState(testWalletAddress); got != first || got.Height != 850000 {
+		t.Errorf("first address state height = %d, want 850000", got.Height)
+	}
+
+	// 재시작 후에도 주소별로 이어서 스캔
+	restarted := &compactFilterBackend{birthday: segwitActivationHeight}
+	if got := restarted.loadState(testWalletAddress).Height; got != 850000 {
+		t.Errorf("reloaded first address height = %d, want 850000", got)
+	}
+	if got := restarted.loadState(other).Height; got != 840000 {
+		t.Errorf("reloaded second address height = %d, want 840000", got)
+	}
+}
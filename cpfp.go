@@ -0,0 +1,289 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// CPFPRequest 자식 거래로 부모 거래 가속(CPFP) 요청 구조체
+type CPFPRequest struct {
+	WalletData    WalletData `json:"walletData"`    // 지갑 데이터
+	ParentTxID    string     `json:"parentTxid"`    // 가속할 미확인 부모 거래 ID
+	TargetFeeRate float64    `json:"targetFeeRate"` // 부모+자식 패키지 목표 수수료율 (sat/vB)
+}
+
+// CPFPResponse CPFP 응답 구조체
+type CPFPResponse struct {
+	Success           bool    `json:"success"`           // 성공 여부
+	Message           string  `json:"message"`           // 응답 메시지
+	ErrorCode         string  `json:"errorCode"`         // 에러 코드 (다국어 처리용)
+	TxHash            string  `json:"txHash"`            // 자식 거래 해시
+	ParentFee         int64   `json:"parentFee"`         // 부모 거래 수수료 (satoshi)
+	ParentVSize       int64   `json:"parentVSize"`       // 부모 거래 크기 (vbyte)
+	ChildFee          int64   `json:"childFee"`          // 자식 거래 수수료 (satoshi)
+	ChildVSize        int64   `json:"childVSize"`        // 자식 거래 크기 (vbyte)
+	PackageFeeRate    float64 `json:"packageFeeRate"`    // 패키지 수수료율 (sat/vB)
+	ReceivedAmountSat int64   `json:"receivedAmountSat"` // 지갑으로 돌아오는 금액 (satoshi)
+}
+
+// cpfpChildFee 패키지가 목표 수수료율에 도달하도록 자식 거래 수수료 계산
+// 최소 중계 수수료(1 sat/vB) 이상을 보장
+func cpfpChildFee(parentFee, parentVSize, childVSize int64, targetFeeRate float64) int64 {
+	packageFee := int64(math.Ceil(targetFeeRate * float64(parentVSize+childVSize)))
+	childFee := packageFee - parentFee
+	minFee := int64(math.Ceil(incrementalRelayFeeRate * float64(childVSize)))
+	if childFee < minFee {
+		return minFee
+	}
+	return childFee
+}
+
+// txFeeFromPrevouts 이전 출력 금액으로 거래 수수료를 직접 계산 (이전 출력을 모르는 입력이 있으면 false)
+func txFeeFromPrevouts(details *TxDetails) (int64, bool) {
+	if len(details.Vin) == 0 {
+		return 0, false
+	}
+	var totalInput, totalOutput int64
+	for _, vin := range details.Vin {
+		if vin.Prevout == nil {
+			return 0, false
+		}
+		totalInput += vin.Prevout.Value
+	}
+	for _, vout := range details.Vout {
+		totalOutput += vout.Value
+	}
+	if totalInput < totalOutput {
+		return 0, false
+	}
+	return totalInput - totalOutput, true
+}
+
+// AccelerateTransaction 미확인 부모 거래의 지갑 출력을 다시 지갑으로 보내 패키지 수수료율을 올림 (CPFP)
+func (a *App) AccelerateTransaction(request CPFPRequest) CPFPResponse {
+	if request.ParentTxID == "" {
+		return CPFPResponse{
+			Success: false,
+			Message: "거래 ID를 입력해주세요",
+		}
+	}
+
+	if request.TargetFeeRate <= 0 {
+		return CPFPResponse{
+			Success:   false,
+			Message:   "수수료율은 0보다 커야 합니다",
+			ErrorCode: "FEE_RATE_INVALID",
+		}
+	}
+	if request.TargetFeeRate > maxBumpFeeRate {
+		return CPFPResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("수수료율이 너무 높습니다. 최대 %.0f sat/vB를 초과할 수 없습니다.", maxBumpFeeRate),
+			ErrorCode: "FEE_RATE_TOO_HIGH",
+		}
+	}
+
+	// 1. 부모 거래 조회
+	parent, err := a.fetchTxDetails(request.ParentTxID)
+	if err != nil {
+		return CPFPResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("거래 세부정보 조회 실패: %v", err),
+			ErrorCode: backendErrorCode(err),
+		}
+	}
+
+	if parent.Status.Confirmed {
+		return CPFPResponse{
+			Success:   false,
+			Message:   "이미 확인된 거래는 가속할 필요가 없습니다",
+			ErrorCode: "TX_ALREADY_CONFIRMED",
+		}
+	}
+
+	// 백엔드는 이전 출력을 모르면 수수료를 0으로 두므로 이전 출력 금액으로 직접 계산
+	parentFee, ok := txFeeFromPrevouts(parent)
+	if !ok {
+		return CPFPResponse{
+			Success:   false,
+			Message:   "부모 거래의 이전 출력을 조회할 수 없어 수수료를 계산할 수 없습니다",
+			ErrorCode: "PARENT_FEE_UNKNOWN",
+		}
+	}
+
+	parentVSize := (parent.Weight + 3) / 4
+	if parentVSize > 0 && float64(parentFee)/float64(parentVSize) >= request.TargetFeeRate {
+		return CPFPResponse{
+			Success:   false,
+			Message:   "부모 거래의 수수료율이 이미 목표 수수료율 이상입니다",
+			ErrorCode: "FEE_RATE_ALREADY_MET",
+			ParentFee: parentFee,
+		}
+	}
+
+	// 2. 부모 거래에서 지갑으로 들어온 출력 찾기
+	walletAddr, err := btcutil.DecodeAddress(request.WalletData.Address, &chaincfg.MainNetParams)
+	if err != nil {
+		return CPFPResponse{
+			Success: false,
+			Message: fmt.Sprintf("지갑 주소 파싱 실패: %v", err),
+		}
+	}
+	walletScript, err := txscript.PayToAddrScript(walletAddr)
+	if err != nil {
+		return CPFPResponse{
+			Success: false,
+			Message: fmt.Sprintf("지갑 스크립트 생성 실패: %v", err),
+		}
+	}
+
+	parentHash, err := chainhash.NewHashFromStr(parent.TxID)
+	if err != nil {
+		return CPFPResponse{
+			Success: false,
+			Message: fmt.Sprintf("거래 해시 생성 실패: %v", err),
+		}
+	}
+
+	// 아직 사용되지 않은 출력만 대상 (미확인 UTXO 포함 조회)
+	utxos, err := a.fetchAllUTXOs(request.WalletData.Address)
+	if err != nil {
+		return CPFPResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("UTXO 조회 실패: %v", err),
+			ErrorCode: backendErrorCode(err),
+		}
+	}
+	unspent := make(map[int]bool)
+	var confirmed []UTXO
+	for _, utxo := range utxos {
+		if utxo.TxID == parent.TxID {
+			unspent[utxo.Vout] = true
+		} else if utxo.Status.Confirmed {
+			confirmed = append(confirmed, utxo)
+		}
+	}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	var prevOutScripts [][]byte
+	var prevOutValues []int64
+	var totalInput int64
+	for i, vout := range parent.Vout {
+		if !unspent[i] || vout.ScriptPubKey != hex.EncodeToString(walletScript) {
+			continue
+		}
+		txIn := wire.NewTxIn(wire.NewOutPoint(parentHash, uint32(i)), nil, nil)
+		txIn.Sequence = rbfSequence
+		tx.AddTxIn(txIn)
+		prevOutScripts = append(prevOutScripts, walletScript)
+		prevOutValues = append(prevOutValues, vout.Value)
+		totalInput += vout.Value
+	}
+
+	if len(tx.TxIn) == 0 {
+		return CPFPResponse{
+			Success:   false,
+			Message:   "부모 거래에 이 지갑이 사용할 수 있는 출력이 없습니다",
+			ErrorCode: "NO_SPENDABLE_OUTPUT",
+		}
+	}
+
+	// 3. 자식 거래 수수료 계산 (부족하면 확인된 UTXO 추가)
+	sort.Slice(confirmed, func(i, j int) bool {
+		return confirmed[i].Value > confirmed[j].Value
+	})
+	tx.AddTxOut(wire.NewTxOut(0, walletScript))
+
+	var childFee, childVSize int64
+	for {
+		childVSize = estimateVSize(tx)
+		childFee = cpfpChildFee(parentFee, parentVSize, childVSize, request.TargetFeeRate)
+		if totalInput-childFee >= 546 {
+			break
+		}
+
+		if len(confirmed) == 0 {
+			return CPFPResponse{
+				Success:   false,
+				Message:   fmt.Sprintf("잔액이 부족합니다. 필요 수수료: %d satoshi, 보유: %d satoshi", childFee, totalInput),
+				ErrorCode: "INSUFFICIENT_FUNDS",
+				ParentFee: parentFee,
+			}
+		}
+		next := confirmed[0]
+		confirmed = confirmed[1:]
+		hash, err := chainhash.NewHashFromStr(next.TxID)
+		if err != nil {
+			continue
+		}
+		txIn := wire.NewTxIn(wire.NewOutPoint(hash, uint32(next.Vout)), nil, nil)
+		txIn.Sequence = rbfSequence
+		tx.AddTxIn(txIn)
+		prevOutScripts = append(prevOutScripts, walletScript)
+		prevOutValues = append(prevOutValues, next.Value)
+		totalInput += next.Value
+	}
+	tx.TxOut[0].Value = totalInput - childFee
+
+	// 4. 서명
+	privKeyWIF, err := btcutil.DecodeWIF(request.WalletData.PrivateKeyWIF)
+	if err != nil {
+		return CPFPResponse{
+			Success: false,
+			Message: fmt.Sprintf("개인키 디코딩 실패: %v", err),
+		}
+	}
+	fetcher, err := a.verifyPrevOuts(tx, prevOutScripts, prevOutValues)
+	if err != nil {
+		return CPFPResponse{
+			Success:   false,
+			Message:   err.Error(),
+			ErrorCode: prevOutErrorCode(err),
+		}
+	}
+	if err := signP2WPKHInputs(tx, fetcher, privKeyWIF); err != nil {
+		return CPFPResponse{
+			Success: false,
+			Message: err.Error(),
+		}
+	}
+
+	// 5. 직렬화 및 브로드캐스트
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return CPFPResponse{
+			Success: false,
+			Message: fmt.Sprintf("거래 직렬화 실패: %v", err),
+		}
+	}
+
+	txHash, err := a.broadcastTransaction(hex.EncodeToString(buf.Bytes()))
+	if err != nil {
+		return CPFPResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("거래 브로드캐스트 실패: %v", err),
+			ErrorCode: backendErrorCode(err),
+		}
+	}
+
+	return CPFPResponse{
+		Success:           true,
+		Message:           "가속 거래가 성공적으로 전송되었습니다",
+		TxHash:            txHash,
+		ParentFee:         parentFee,
+		ParentVSize:       parentVSize,
+		ChildFee:          childFee,
+		ChildVSize:        childVSize,
+		PackageFeeRate:    float64(parentFee+childFee) / float64(parentVSize+childVSize),
+		ReceivedAmountSat: tx.TxOut[0].Value,
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import "testing"
+
+func TestAccelerateTransactionRequiresParentPrevouts(t *testing.T) {
+	backend := newFakeBackend()
+	// 이전 출력을 모르는 백엔드는 수수료를 0으로 둠
+	backend.txs[testTxID(1)] = &TxDetails{
+		TxID:   testTxID(1),
+		Vin:    []TxInput{{TxID: testTxID(2), Prevout: &TxOutput{Value: 60_000}}, {TxID: testTxID(3)}},
+		Vout:   []TxOutput{{ScriptPubKeyAddress: testWalletAddress, Value: 50_000}},
+		Weight: 800,
+	}
+	app := newTestApp(backend)
+
+	response := app.AccelerateTransaction(CPFPRequest{
+		WalletData:    WalletData{Address: testWalletAddress},
+		ParentTxID:    testTxID(1),
+		TargetFeeRate: 10,
+	})
+	if response.Success || response.ErrorCode != "PARENT_FEE_UNKNOWN" {
+		t.Fatalf("AccelerateTransaction = %+v, want PARENT_FEE_UNKNOWN", response)
+	}
+
+	// 모든 이전 출력을 알면 백엔드의 수수료 대신 직접 계산한 수수료로 판단
+	backend.txs[testTxID(1)].Vin[1].Prevout = &TxOutput{Value: 40_000}
+	response = app.AccelerateTransaction(CPFPRequest{
+		WalletData:    WalletData{Address: testWalletAddress},
+		ParentTxID:    testTxID(1),
+		TargetFeeRate: 10,
+	})
+	if response.ErrorCode != "FEE_RATE_ALREADY_MET" || response.ParentFee != 50_000 {
+		t.Errorf("AccelerateTransaction = %+v, want FEE_RATE_ALREADY_MET with parent fee 50000", response)
+	}
+}
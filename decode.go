@@ -0,0 +1,326 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// DecodeTransactionRequest 거래 디코딩 요청 구조체
+type DecodeTransactionRequest struct {
+	Data          string `json:"data"`          // 서명된 거래 hex 또는 PSBT (base64/hex)
+	FetchPrevouts bool   `json:"fetchPrevouts"` // 입력 금액을 알 수 없을 때 API로 이전 출력 조회 여부 (온라인 필요)
+}
+
+// DecodedInput 디코딩된 거래 입력
+type DecodedInput struct {
+	TxID        string `json:"txid"`        // 이전 거래 ID
+	Vout        uint32 `json:"vout"`        // 이전 출력 인덱스
+	Sequence    uint32 `json:"sequence"`    // 시퀀스
+	WitnessType string `json:"witnessType"` // 입력 종류 (p2wpkh, p2tr, p2wsh, p2sh-p2wpkh, legacy, unsigned)
+	ValueKnown  bool   `json:"valueKnown"`  // 입력 금액 확인 여부
+	Verified    bool   `json:"verified"`    // 입력 금액을 이전 거래 원본(txid 일치)으로 검증했는지 여부
+	AmountSat   int64  `json:"amountSat"`   // 입력 금액 (satoshi, 확인된 경우)
+	Address     string `json:"address"`     // 이전 출력 주소 (확인된 경우)
+}
+
+// DecodedOutput 디코딩된 거래 출력
+type DecodedOutput struct {
+	Index        int    `json:"index"`        // 출력 인덱스
+	Address      string `json:"address"`      // 받는 주소 (OP_RETURN 등은 빈 문자열)
+	ScriptType   string `json:"scriptType"`   // 스크립트 종류
+	ScriptPubKey string `json:"scriptPubKey"` // 출력 스크립트 (hex)
+	AmountSat    int64  `json:"amountSat"`    // 출력 금액 (satoshi)
+	OpReturnHex  string `json:"opReturnHex"`  // OP_RETURN 데이터 (hex)
+	OpReturnText string `json:"opReturnText"` // OP_RETURN 데이터 (출력 가능한 문자열인 경우)
+}
+
+// DecodeTransactionResponse 거래 디코딩 응답 구조체
+type DecodeTransactionResponse struct {
+	Success     bool            `json:"success"`     // 성공 여부
+	Message     string          `json:"message"`     // 응답 메시지
+	ErrorCode   string          `json:"errorCode"`   // 에러 코드 (다국어 처리용)
+	Format      string          `json:"format"`      // 입력 형식 (raw, psbt)
+	TxID        string          `json:"txid"`        // 거래 ID
+	WTxID       string          `json:"wtxid"`       // witness 거래 ID (서명된 거래만)
+	Version     int32           `json:"version"`     // 거래 버전
+	Locktime    uint32          `json:"locktime"`    // 잠금 시간
+	Inputs      []DecodedInput  `json:"inputs"`      // 입력 목록
+	Outputs     []DecodedOutput `json:"outputs"`     // 출력 목록
+	VSize       int64           `json:"vsize"`       // 가상 크기 (vbyte, PSBT는 서명 후 추정값)
+	Weight      int64           `json:"weight"`      // 무게 (weight unit)
+	RBF         bool            `json:"rbf"`         // RBF(BIP125) 신호 여부
+	FeeKnown    bool            `json:"feeKnown"`    // 모든 입력 금액이 확인되어 수수료 계산 가능 여부
+	FeeVerified bool            `json:"feeVerified"` // 모든 입력 금액이 이전 거래 원본으로 검증되어 수수료를 신뢰할 수 있는지 여부
+	FeeSat      int64           `json:"feeSat"`      // 수수료 (satoshi)
+	FeeRate     float64         `json:"feeRate"`     // 수수료율 (sat/vB)
+}
+
+// decodeInputData 입력 문자열을 거래 또는 PSBT로 파싱
+func decodeInputData(data string) (*wire.MsgTx, *psbt.Packet, error) {
+	data = strings.TrimSpace(data)
+	if data == "" {
+		return nil, nil, fmt.Errorf("디코딩할 데이터가 없습니다")
+	}
+
+	// base64 PSBT ("psbt\xff" 매직 바이트는 base64로 "cHNidP")
+	if strings.HasPrefix(data, "cHNidP") {
+		packet, err := psbt.NewFromRawBytes(strings.NewReader(data), true)
+		if err != nil {
+			return nil, nil, fmt.Errorf("PSBT 파싱 실패: %v", err)
+		}
+		return packet.UnsignedTx, packet, nil
+	}
+
+	raw, err := hex.DecodeString(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("hex 디코딩 실패: %v", err)
+	}
+
+	// hex PSBT
+	if bytes.HasPrefix(raw, []byte("psbt\xff")) {
+		packet, err := psbt.NewFromRawBytes(bytes.NewReader(raw), false)
+		if err != nil {
+			return nil, nil, fmt.Errorf("PSBT 파싱 실패: %v", err)
+		}
+		return packet.UnsignedTx, packet, nil
+	}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	if err := tx.Deserialize(bytes.NewReader(raw)); err != nil {
+		return nil, nil, fmt.Errorf("거래 파싱 실패: %v", err)
+	}
+	return tx, nil, nil
+}
+
+// classifyInput 서명 스크립트와 witness로 입력 종류 추정
+func classifyInput(txIn *wire.TxIn, prevScript []byte) string {
+	if prevScript != nil {
+		switch txscript.GetScriptClass(prevScript) {
+		case txscript.WitnessV0PubKeyHashTy:
+			return "p2wpkh"
+		case txscript.WitnessV0ScriptHashTy:
+			return "p2wsh"
+		case txscript.WitnessV1TaprootTy:
+			return "p2tr"
+		case txscript.ScriptHashTy:
+			if len(txIn.Witness) > 0 {
+				return "p2sh-p2wpkh"
+			}
+			return "p2sh"
+		case txscript.PubKeyHashTy:
+			return "p2pkh"
+		}
+	}
+
+	witness := txIn.Witness
+	switch {
+	case len(witness) == 0 && len(txIn.SignatureScript) == 0:
+		return "unsigned"
+	case len(witness) == 0:
+		return "legacy"
+	case len(txIn.SignatureScript) > 0:
+		return "p2sh-p2wpkh"
+	case len(witness) == 2 && len(witness[1]) == 33:
+		return "p2wpkh"
+	case len(witness) == 1 && (len(witness[0]) == 64 || len(witness[0]) == 65):
+		return "p2tr"
+	default:
+		return "p2wsh"
+	}
+}
+
+// decodeOutput 출력 스크립트를 주소와 종류로 디코딩
+func decodeOutput(index int, txOut *wire.TxOut) DecodedOutput {
+	output := DecodedOutput{
+		Index:        index,
+		AmountSat:    txOut.Value,
+		ScriptPubKey: hex.EncodeToString(txOut.PkScript),
+	}
+
+	class, addrs, _, err := txscript.ExtractPkScriptAddrs(txOut.PkScript, &chaincfg.MainNetParams)
+	output.ScriptType = class.String()
+	if err == nil && len(addrs) == 1 {
+		output.Address = addrs[0].EncodeAddress()
+	}
+
+	if class == txscript.NullDataTy {
+		pushes, err := txscript.PushedData(txOut.PkScript)
+		if err == nil {
+			data := bytes.Join(pushes, nil)
+			output.OpReturnHex = hex.EncodeToString(data)
+			if isPrintable(data) {
+				output.OpReturnText = string(data)
+			}
+		}
+	}
+
+	return output
+}
+
+// isPrintable OP_RETURN 데이터가 출력 가능한 UTF-8 문자열인지 확인
+func isPrintable(data []byte) bool {
+	if len(data) == 0 {
+		return false
+	}
+	for _, r := range string(data) {
+		if r == unicode.ReplacementChar || !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// DecodeTransaction 서명된 거래 hex 또는 PSBT를 디코딩하여 입력, 출력, 크기, 수수료 반환
+func (a *App) DecodeTransaction(request DecodeTransactionRequest) DecodeTransactionResponse {
+	tx, packet, err := decodeInputData(request.Data)
+	if err != nil {
+		return DecodeTransactionResponse{
+			Success:   false,
+			Message:   err.Error(),
+			ErrorCode: "DECODE_FAILED",
+		}
+	}
+
+	response := DecodeTransactionResponse{
+		Success:  true,
+		Message:  "성공",
+		Format:   "raw",
+		TxID:     tx.TxHash().String(),
+		Version:  tx.Version,
+		Locktime: tx.LockTime,
+		RBF:      signalsRBF(tx),
+	}
+
+	if packet != nil {
+		// PSBT는 서명 전이므로 서명 후 크기를 추정
+		response.Format = "psbt"
+		response.VSize = estimateVSize(tx)
+		response.Weight = response.VSize * 4
+	} else {
+		response.WTxID = tx.WitnessHash().String()
+		response.Weight = int64(tx.SerializeSizeStripped()*3 + tx.SerializeSize())
+		response.VSize = (response.Weight + 3) / 4
+	}
+
+	// 입력 금액 확인에 필요한 이전 거래 원본을 한꺼번에 조회 (실패한 입력은 금액 미확인으로 표시)
+	// 원본의 txid를 로컬에서 다시 계산하여 일치하는 거래만 사용
+	prevTxs := make(map[string]*wire.MsgTx)
+	if request.FetchPrevouts {
+		var txids []string
+		for _, txIn := range tx.TxIn {
+			txids = append(txids, txIn.PreviousOutPoint.Hash.String())
+		}
+		raws, _ := a.fetchRawTransactions(txids)
+		for txid, raw := range raws {
+			if prevTx, computed, err := parseRawTransaction(raw); err == nil && computed == txid {
+				prevTxs[txid] = prevTx
+			}
+		}
+	}
+
+	// 입력 디코딩 (PSBT의 UTXO 정보 또는 API 조회로 금액 확인)
+	feeKnown, feeVerified, mismatch := true, true, false
+	var totalInput int64
+	for i, txIn := range tx.TxIn {
+		input := DecodedInput{
+			TxID:     txIn.PreviousOutPoint.Hash.String(),
+			Vout:     txIn.PreviousOutPoint.Index,
+			Sequence: txIn.Sequence,
+		}
+
+		var pIn *psbt.PInput
+		if packet != nil && i < len(packet.Inputs) {
+			pIn = &packet.Inputs[i]
+		}
+		prevOut, verified, ok := resolvePrevOut(txIn.PreviousOutPoint, pIn, prevTxs[input.TxID])
+		if !ok {
+			mismatch = true
+		}
+
+		var prevScript []byte
+		if prevOut != nil {
+			prevScript = prevOut.PkScript
+			input.ValueKnown = true
+			input.Verified = verified
+			input.AmountSat = prevOut.Value
+			input.Address = decodeOutput(0, prevOut).Address
+			totalInput += prevOut.Value
+		} else {
+			feeKnown = false
+		}
+		if !verified {
+			feeVerified = false
+		}
+		input.WitnessType = classifyInput(txIn, prevScript)
+
+		response.Inputs = append(response.Inputs, input)
+	}
+
+	// 출력 디코딩
+	var totalOutput int64
+	for i, txOut := range tx.TxOut {
+		response.Outputs = append(response.Outputs, decodeOutput(i, txOut))
+		totalOutput += txOut.Value
+	}
+
+	if feeKnown && len(tx.TxIn) > 0 {
+		response.FeeKnown = true
+		response.FeeVerified = feeVerified
+		response.FeeSat = totalInput - totalOutput
+		if response.VSize > 0 {
+			response.FeeRate = float64(response.FeeSat) / float64(response.VSize)
+		}
+		if response.FeeSat < 0 {
+			response.Message = "경고: 출력 합계가 입력 합계보다 큽니다"
+		}
+	}
+	if mismatch {
+		response.Message = "경고: PSBT의 이전 출력 정보가 참조하는 거래와 일치하지 않아 해당 입력 금액을 사용하지 않았습니다"
+	}
+
+	return response
+}
+
+// resolvePrevOut 입력이 참조하는 이전 출력 결정 (출력, 원본 검증 여부, 정보 일치 여부)
+// NonWitnessUtxo와 조회한 원본 거래는 txid가 입력의 이전 거래와 같을 때만 검증된 값으로 사용하고,
+// WitnessUtxo는 단독으로는 검증할 수 없으므로 원본이 있으면 원본과 같은지 확인함
+// 정보가 서로 다르면 위조된 PSBT일 수 있으므로 금액을 미확인으로 처리함
+func resolvePrevOut(outPoint wire.OutPoint, pIn *psbt.PInput, prevTx *wire.MsgTx) (*wire.TxOut, bool, bool) {
+	var original *wire.TxOut
+	if pIn != nil && pIn.NonWitnessUtxo != nil {
+		if pIn.NonWitnessUtxo.TxHash() != outPoint.Hash || int(outPoint.Index) >= len(pIn.NonWitnessUtxo.TxOut) {
+			return nil, false, false
+		}
+		original = pIn.NonWitnessUtxo.TxOut[outPoint.Index]
+	}
+	if prevTx != nil && int(outPoint.Index) < len(prevTx.TxOut) {
+		fetched := prevTx.TxOut[outPoint.Index]
+		if original != nil && !sameTxOut(original, fetched) {
+			return nil, false, false
+		}
+		original = fetched
+	}
+
+	if pIn != nil && pIn.WitnessUtxo != nil {
+		if original == nil {
+			return pIn.WitnessUtxo, false, true
+		}
+		if !sameTxOut(original, pIn.WitnessUtxo) {
+			return nil, false, false
+		}
+	}
+	return original, original != nil, true
+}
+
+// sameTxOut 두 출력의 금액과 스크립트가 같은지 확인
+func sameTxOut(a, b *wire.TxOut) bool {
+	return a.Value == b.Value && bytes.Equal(a.PkScript, b.PkScript)
+}
@@ -0,0 +1,807 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	// electrumProtocolVersion 요청하는 Electrum 프로토콜 버전
+	electrumProtocolVersion = "1.4"
+
+	// electrumCallTimeout Electrum 요청 응답 대기 시간
+	electrumCallTimeout = 30 * time.Second
+)
+
+// electrumResponse Electrum JSON-RPC 응답/알림 메시지
+type electrumResponse struct {
+	ID     *int            `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  json.RawMessage `json:"error"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// electrumUTXO blockchain.scripthash.listunspent 결과 항목
+type electrumUTXO struct {
+	TxHash string `json:"tx_hash"`
+	TxPos  int    `json:"tx_pos"`
+	Height int64  `json:"height"`
+	Value  int64  `json:"value"`
+}
+
+// electrumHistory blockchain.scripthash.get_history 결과 항목
+type electrumHistory struct {
+	TxHash string `json:"tx_hash"`
+	Height int64  `json:"height"`
+}
+
+// electrumUTXOCache 스크립트 해시 상태별 UTXO 캐시 (상태가 바뀌면 다시 조회)
+type electrumUTXOCache struct {
+	status string
+	utxos  []UTXO
+}
+
+// electrumBackend Electrum 프로토콜 백엔드 (Electrs, Fulcrum, ElectrumX)
+// 주소는 스크립트 해시로 구독하고, 서버 알림으로 상태가 바뀐 경우에만 UTXO를 다시 조회함
+type electrumBackend struct {
+	ctx    context.Context
+	server string
+	dial   func() (net.Conn, error)
+
+	connMu  sync.Mutex // 연결 수립과 버전 협상을 한 번에 하나만 진행 (다른 요청은 협상이 끝날 때까지 대기)
+	mu      sync.Mutex // 연결 및 대기 요청 보호
+	conn    net.Conn
+	ready   bool // 버전 협상이 끝나 다른 요청을 보낼 수 있는 상태
+	writer  *bufio.Writer
+	nextID  int
+	pending map[int]chan electrumResponse
+
+	cacheMu   sync.Mutex
+	statuses  map[string]string // 스크립트 해시별 최신 상태 (구독 알림으로 갱신)
+	utxoCache map[string]electrumUTXOCache
+}
+
+// newElectrumBackend Electrum 백엔드 생성
+// rawURL 예: tcp://127.0.0.1:50001, ssl://electrum.example.com:50002
+func newElectrumBackend(ctx context.Context, rawURL string, tlsSkipVerify bool, dialContext dialContextFunc) (*electrumBackend, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("잘못된 Electrum 주소: %s", rawURL)
+	}
+
+	var dial func() (net.Conn, error)
+	switch parsed.Scheme {
+	case "tcp":
+		dial = func() (net.Conn, error) {
+			ctx, cancel := context.WithTimeout(ctx, electrumCallTimeout)
+			defer cancel()
+			return dialContext(ctx, "tcp", parsed.Host)
+		}
+	case "ssl", "tls":
+		dial = func() (net.Conn, error) {
+			ctx, cancel := context.WithTimeout(ctx, electrumCallTimeout)
+			defer cancel()
+			conn, err := dialContext(ctx, "tcp", parsed.Host)
+			if err != nil {
+				return nil, err
+			}
+			tlsConn := tls.Client(conn, &tls.Config{
+				ServerName:         parsed.Hostname(),
+				InsecureSkipVerify: tlsSkipVerify, // 자체 서명 인증서 사용 서버
+			})
+			if err := tlsConn.HandshakeContext(ctx); err != nil {
+				conn.Close()
+				return nil, err
+			}
+			return tlsConn, nil
+		}
+	default:
+		return nil, fmt.Errorf("지원되지 않는 Electrum 스킴: %s (tcp, ssl 사용)", parsed.Scheme)
+	}
+
+	return newElectrumBackendWithDialer(ctx, parsed.Host, dial), nil
+}
+
+// newElectrumBackendWithDialer 연결 함수를 직접 지정하여 Electrum 백엔드 생성 (로컬 모의 서버 등)
+func newElectrumBackendWithDialer(ctx context.Context, server string, dial func() (net.Conn, error)) *electrumBackend {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &electrumBackend{
+		ctx:       ctx,
+		server:    server,
+		dial:      dial,
+		pending:   make(map[int]chan electrumResponse),
+		statuses:  make(map[string]string),
+		utxoCache: make(map[string]electrumUTXOCache),
+	}
+}
+
+// Name 백엔드 이름
+func (e *electrumBackend) Name() string {
+	return "electrum(" + e.server + ")"
+}
+
+// connect 서버 연결 및 버전 협상 (이미 협상된 연결이 있으면 그대로 사용)
+// 협상 중에는 connMu를 잡고 있으므로 동시에 들어온 요청은 협상이 끝난 뒤에야 연결을 사용함
+func (e *electrumBackend) connect() error {
+	e.connMu.Lock()
+	defer e.connMu.Unlock()
+
+	e.mu.Lock()
+	ready := e.conn != nil && e.ready
+	e.mu.Unlock()
+	if ready {
+		return nil
+	}
+
+	conn, err := e.dial()
+	if err != nil {
+		return fmt.Errorf("Electrum 서버 연결 실패: %w", transportError(err))
+	}
+
+	e.mu.Lock()
+	e.conn = conn
+	e.ready = false
+	e.writer = bufio.NewWriter(conn)
+	go e.readLoop(conn)
+
+	// 새 연결이므로 이전 구독 상태는 무효
+	e.cacheMu.Lock()
+	e.statuses = make(map[string]string)
+	e.utxoCache = make(map[string]electrumUTXOCache)
+	e.cacheMu.Unlock()
+
+	ch, err := e.sendLocked("server.version", "gowallet", electrumProtocolVersion)
+	if err != nil {
+		e.closeLocked()
+		e.mu.Unlock()
+		return err
+	}
+	e.mu.Unlock()
+
+	// 버전 응답은 연결 잠금을 풀고 기다려야 readLoop가 전달할 수 있음
+	_, err = e.wait(ch)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err != nil {
+		if e.conn == conn {
+			e.closeLocked()
+		}
+		return fmt.Errorf("Electrum 버전 협상 실패: %w", err)
+	}
+	if e.conn != conn {
+		return &backendError{code: ErrorCodeBackendUnavailable, err: fmt.Errorf("Electrum 서버 연결이 끊어졌습니다")}
+	}
+	e.ready = true
+	return nil
+}
+
+// closeLocked 연결 종료 및 대기 중인 요청 실패 처리 (e.mu 보유 상태에서 호출)
+func (e *electrumBackend) closeLocked() {
+	if e.conn != nil {
+		e.conn.Close()
+		e.conn = nil
+	}
+	e.ready = false
+	for id, ch := range e.pending {
+		close(ch)
+		delete(e.pending, id)
+	}
+}
+
+// sendLocked 요청 전송 후 응답 채널 반환 (e.mu 보유 상태에서 호출)
+func (e *electrumBackend) sendLocked(method string, params ...interface{}) (chan electrumResponse, error) {
+	e.nextID++
+	id := e.nextID
+
+	if params == nil {
+		params = []interface{}{}
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      id,
+		"method":  method,
+		"params":  params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan electrumResponse, 1)
+	e.pending[id] = ch
+
+	if _, err := e.writer.Write(append(payload, '\n')); err != nil {
+		delete(e.pending, id)
+		return nil, fmt.Errorf("Electrum 요청 전송 실패: %w", transportError(err))
+	}
+	if err := e.writer.Flush(); err != nil {
+		delete(e.pending, id)
+		return nil, fmt.Errorf("Electrum 요청 전송 실패: %w", transportError(err))
+	}
+
+	return ch, nil
+}
+
+// wait 응답 대기 (시간 초과 시 오류)
+func (e *electrumBackend) wait(ch chan electrumResponse) (json.RawMessage, error) {
+	select {
+	case resp, ok := <-ch:
+		if !ok {
+			return nil, &backendError{code: ErrorCodeBackendUnavailable, err: fmt.Errorf("Electrum 서버 연결이 끊어졌습니다")}
+		}
+		if len(resp.Error) > 0 && string(resp.Error) != "null" {
+			var rpcErr struct {
+				Code    int    `json:"code"`
+				Message string `json:"message"`
+			}
+			if json.Unmarshal(resp.Error, &rpcErr) == nil && rpcErr.Message != "" {
+				return nil, fmt.Errorf("Electrum 오류 %d: %s", rpcErr.Code, rpcErr.Message)
+			}
+			return nil, fmt.Errorf("Electrum 오류: %s", string(resp.Error))
+		}
+		return resp.Result, nil
+	case <-time.After(electrumCallTimeout):
+		return nil, &backendError{code: ErrorCodeBackendTimeout, err: fmt.Errorf("Electrum 응답 시간 초과")}
+	case <-e.ctx.Done():
+		return nil, transportError(e.ctx.Err())
+	}
+}
+
+// readLoop 서버 메시지 수신 (응답은 대기 중인 요청에 전달, 구독 알림은 상태 갱신)
+func (e *electrumBackend) readLoop(conn net.Conn) {
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			e.mu.Lock()
+			if e.conn == conn {
+				e.closeLocked()
+			}
+			e.mu.Unlock()
+			return
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		var msg electrumResponse
+		if err := json.Unmarshal(line, &msg); err != nil {
+			continue
+		}
+
+		if msg.ID == nil {
+			e.handleNotification(msg)
+			continue
+		}
+
+		e.mu.Lock()
+		ch, ok := e.pending[*msg.ID]
+		delete(e.pending, *msg.ID)
+		e.mu.Unlock()
+		if ok {
+			ch <- msg
+		}
+	}
+}
+
+// handleNotification 구독 알림 처리 (스크립트 해시 상태 변경 시 캐시 무효화)
+func (e *electrumBackend) handleNotification(msg electrumResponse) {
+	if msg.Method != "blockchain.scripthash.subscribe" {
+		return
+	}
+
+	var params []*string
+	if err := json.Unmarshal(msg.Params, &params); err != nil || len(params) < 2 || params[0] == nil {
+		return
+	}
+
+	status := ""
+	if params[1] != nil {
+		status = *params[1]
+	}
+
+	e.cacheMu.Lock()
+	e.statuses[*params[0]] = status
+	delete(e.utxoCache, *params[0])
+	e.cacheMu.Unlock()
+}
+
+// call 요청 전송 후 결과를 result에 디코딩 (연결이 끊어졌으면 한 번 재연결)
+func (e *electrumBackend) call(result interface{}, method string, params ...interface{}) error {
+	var raw json.RawMessage
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if err = e.connect(); err != nil {
+			return err
+		}
+
+		// 협상 직후 연결이 끊어졌으면 다시 연결
+		e.mu.Lock()
+		if e.conn == nil || !e.ready {
+			e.mu.Unlock()
+			err = &backendError{code: ErrorCodeBackendUnavailable, err: fmt.Errorf("Electrum 서버 연결이 끊어졌습니다")}
+			continue
+		}
+		var ch chan electrumResponse
+		ch, err = e.sendLocked(method, params...)
+		if err != nil {
+			e.closeLocked()
+			e.mu.Unlock()
+			continue
+		}
+		e.mu.Unlock()
+
+		raw, err = e.wait(ch)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if result == nil {
+		return nil
+	}
+	if err := json.Unmarshal(raw, result); err != nil {
+		return fmt.Errorf("Electrum 응답 파싱 실패 (%s): %v", method, err)
+	}
+	return nil
+}
+
+// addressScriptHash 주소를 Electrum 스크립트 해시로 변환 (sha256(scriptPubKey)를 뒤집은 hex)
+func addressScriptHash(address string) (string, []byte, error) {
+	decoded, err := btcutil.DecodeAddress(address, &chaincfg.MainNetParams)
+	if err != nil {
+		return "", nil, fmt.Errorf("주소 형식 오류: %v", err)
+	}
+	script, err := txscript.PayToAddrScript(decoded)
+	if err != nil {
+		return "", nil, fmt.Errorf("스크립트 생성 실패: %v", err)
+	}
+	return scriptHashHex(script), script, nil
+}
+
+// scriptHashHex 출력 스크립트의 Electrum 스크립트 해시
+func scriptHashHex(script []byte) string {
+	hash := sha256.Sum256(script)
+	for i, j := 0, len(hash)-1; i < j; i, j = i+1, j-1 {
+		hash[i], hash[j] = hash[j], hash[i]
+	}
+	return hex.EncodeToString(hash[:])
+}
+
+// subscribe 스크립트 해시 구독 후 현재 상태 반환
+func (e *electrumBackend) subscribe(scriptHash string) (string, error) {
+	var status *string
+	if err := e.call(&status, "blockchain.scripthash.subscribe", scriptHash); err != nil {
+		return "", err
+	}
+
+	current := ""
+	if status != nil {
+		current = *status
+	}
+
+	e.cacheMu.Lock()
+	e.statuses[scriptHash] = current
+	e.cacheMu.Unlock()
+	return current, nil
+}
+
+// AddressUTXOs 주소의 모든 UTXO 조회 (미확인 UTXO 포함, 상태가 같으면 캐시 사용)
+func (e *electrumBackend) AddressUTXOs(address string) ([]UTXO, error) {
+	scriptHash, _, err := addressScriptHash(address)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := e.subscribe(scriptHash)
+	if err != nil {
+		return nil, fmt.Errorf("UTXO 조회 실패: %w", err)
+	}
+
+	e.cacheMu.Lock()
+	cached, ok := e.utxoCache[scriptHash]
+	e.cacheMu.Unlock()
+	if ok && cached.status == status {
+		return append([]UTXO(nil), cached.utxos...), nil
+	}
+
+	var unspent []electrumUTXO
+	if err := e.call(&unspent, "blockchain.scripthash.listunspent", scriptHash); err != nil {
+		return nil, fmt.Errorf("UTXO 조회 실패: %w", err)
+	}
+
+	utxos := make([]UTXO, 0, len(unspent))
+	for _, item := range unspent {
+		utxo := UTXO{
+			TxID:  item.TxHash,
+			Vout:  item.TxPos,
+			Value: item.Value,
+		}
+		// 높이가 0 이하이면 멤풀에 있는 미확인 거래
+		if item.Height > 0 {
+			utxo.Status.Confirmed = true
+			utxo.Status.BlockHeight = item.Height
+		}
+		utxos = append(utxos, utxo)
+	}
+
+	e.cacheMu.Lock()
+	e.utxoCache[scriptHash] = electrumUTXOCache{status: status, utxos: utxos}
+	e.cacheMu.Unlock()
+
+	return append([]UTXO(nil), utxos...), nil
+}
+
+// AddressStats 주소 통계 조회
+// Electrum은 입출금 합계 대신 잔액만 제공하므로 잔액을 funded 합계로 기록
+func (e *electrumBackend) AddressStats(address string) (*AddressStats, error) {
+	scriptHash, _, err := addressScriptHash(address)
+	if err != nil {
+		return nil, err
+	}
+
+	var balance struct {
+		Confirmed   int64 `json:"confirmed"`
+		Unconfirmed int64 `json:"unconfirmed"`
+	}
+	if err := e.call(&balance, "blockchain.scripthash.get_balance", scriptHash); err != nil {
+		return nil, fmt.Errorf("주소 통계 조회 실패: %w", err)
+	}
+
+	var history []electrumHistory
+	if err := e.call(&history, "blockchain.scripthash.get_history", scriptHash); err != nil {
+		return nil, fmt.Errorf("주소 통계 조회 실패: %w", err)
+	}
+
+	stats := &AddressStats{}
+	stats.ChainStats.FundedTxoSum = balance.Confirmed
+	stats.MempoolStats.FundedTxoSum = balance.Unconfirmed
+	for _, item := range history {
+		if item.Height > 0 {
+			stats.ChainStats.TxCount++
+		} else {
+			stats.MempoolStats.TxCount++
+		}
+	}
+
+	return stats, nil
+}
+
+// AddressTransactions 주소의 거래 내역 조회 (Electrum은 전체 내역을 한 번에 반환하므로 한 페이지)
+func (e *electrumBackend) AddressTransactions(address, lastSeenTxID string) ([]TxDetails, error) {
+	if lastSeenTxID != "" {
+		return nil, nil
+	}
+
+	scriptHash, _, err := addressScriptHash(address)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []electrumHistory
+	if err := e.call(&history, "blockchain.scripthash.get_history", scriptHash); err != nil {
+		return nil, fmt.Errorf("거래 내역 조회 실패: %w", err)
+	}
+
+	// 지갑 스크립트 해시의 내역에 있는 높이로 확인 상태를 정함
+	txids := make([]string, len(history))
+	heights := make(map[string]int64, len(history))
+	for i, item := range history {
+		txids[i] = item.TxHash
+		heights[item.TxHash] = item.Height
+	}
+	return transactionsByID(txids, func(txid string) (*TxDetails, error) {
+		height := heights[txid]
+		return e.transaction(txid, &height)
+	})
+}
+
+// RawTransaction 직렬화된 원본 거래 조회
+func (e *electrumBackend) RawTransaction(txid string) ([]byte, error) {
+	var rawHex string
+	if err := e.call(&rawHex, "blockchain.transaction.get", txid); err != nil {
+		return nil, fmt.Errorf("원본 거래 조회 실패: %w", err)
+	}
+
+	raw, err := hex.DecodeString(rawHex)
+	if err != nil {
+		return nil, fmt.Errorf("거래 hex 디코딩 실패: %v", err)
+	}
+	return raw, nil
+}
+
+// rawTransaction 원시 거래 조회 후 파싱
+func (e *electrumBackend) rawTransaction(txid string) (*wire.MsgTx, error) {
+	raw, err := e.RawTransaction(txid)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	if err := tx.Deserialize(bytes.NewReader(raw)); err != nil {
+		return nil, fmt.Errorf("거래 파싱 실패: %v", err)
+	}
+	return tx, nil
+}
+
+// blockHeader 블록 헤더 조회
+func (e *electrumBackend) blockHeader(height int64) (*wire.BlockHeader, error) {
+	var headerHex string
+	if err := e.call(&headerHex, "blockchain.block.header", height); err != nil {
+		return nil, err
+	}
+
+	raw, err := hex.DecodeString(headerHex)
+	if err != nil {
+		return nil, fmt.Errorf("블록 헤더 디코딩 실패: %v", err)
+	}
+
+	var header wire.BlockHeader
+	if err := header.Deserialize(bytes.NewReader(raw)); err != nil {
+		return nil, fmt.Errorf("블록 헤더 파싱 실패: %v", err)
+	}
+	return &header, nil
+}
+
+// Transaction 거래 세부정보 조회
+// 이전 출력은 입력별 원시 거래로, 확인 상태는 입출력 스크립트 해시의 거래 내역으로 확인
+func (e *electrumBackend) Transaction(txid string) (*TxDetails, error) {
+	return e.transaction(txid, nil)
+}
+
+// transaction 거래 세부정보 조회 (height가 nil이면 입출력 스크립트 해시의 거래 내역에서 높이를 찾음)
+func (e *electrumBackend) transaction(txid string, height *int64) (*TxDetails, error) {
+	tx, err := e.rawTransaction(txid)
+	if err != nil {
+		return nil, fmt.Errorf("거래 세부정보 조회 실패: %w", err)
+	}
+
+	details := txDetailsFromMsgTx(tx)
+
+	// 이전 출력 조회 (수수료 계산 및 입력 주소 확인용)
+	var totalInput, totalOutput int64
+	var prevScripts [][]byte
+	for i, txIn := range tx.TxIn {
+		if blockchainIsCoinbase(txIn) {
+			continue
+		}
+		prevTx, err := e.rawTransaction(txIn.PreviousOutPoint.Hash.String())
+		if err != nil {
+			return nil, fmt.Errorf("이전 거래 조회 실패: %w", err)
+		}
+		if int(txIn.PreviousOutPoint.Index) >= len(prevTx.TxOut) {
+			return nil, fmt.Errorf("잘못된 이전 출력 인덱스: %s:%d", txIn.PreviousOutPoint.Hash, txIn.PreviousOutPoint.Index)
+		}
+		prevTxOut := prevTx.TxOut[txIn.PreviousOutPoint.Index]
+		prevOut := txOutputFromWire(prevTxOut)
+		details.Vin[i].Prevout = &prevOut
+		prevScripts = append(prevScripts, prevTxOut.PkScript)
+		totalInput += prevOut.Value
+	}
+	for _, txOut := range tx.TxOut {
+		totalOutput += txOut.Value
+	}
+	if totalInput > 0 {
+		details.Fee = totalInput - totalOutput
+	}
+
+	// 확인 상태 조회
+	if height == nil {
+		scripts := prevScripts
+		for _, txOut := range tx.TxOut {
+			scripts = append(scripts, txOut.PkScript)
+		}
+		found, err := e.txHeight(txid, scripts)
+		if err != nil {
+			return nil, err
+		}
+		height = &found
+	}
+	if *height > 0 {
+		header, err := e.blockHeader(*height)
+		if err != nil {
+			return nil, fmt.Errorf("블록 헤더 조회 실패: %w", err)
+		}
+		details.Status = TxStatus{
+			Confirmed:   true,
+			BlockHeight: *height,
+			BlockHash:   header.BlockHash().String(),
+			BlockTime:   header.Timestamp.Unix(),
+		}
+	}
+
+	return details, nil
+}
+
+// txHeight 스크립트 해시의 거래 내역에서 거래의 블록 높이를 찾음 (0 이하면 미확인)
+// OP_RETURN 출력이나 내역이 너무 많아 서버가 거부하는 스크립트가 있으므로 찾을 때까지 차례로 시도
+func (e *electrumBackend) txHeight(txid string, scripts [][]byte) (int64, error) {
+	tried := make(map[string]bool)
+	var lastErr error
+	for _, script := range scripts {
+		if len(script) == 0 || txscript.IsUnspendable(script) {
+			continue
+		}
+		scriptHash := scriptHashHex(script)
+		if tried[scriptHash] {
+			continue
+		}
+		tried[scriptHash] = true
+
+		var history []electrumHistory
+		if err := e.call(&history, "blockchain.scripthash.get_history", scriptHash); err != nil {
+			lastErr = err
+			continue
+		}
+		for _, item := range history {
+			if item.TxHash == txid {
+				return item.Height, nil
+			}
+		}
+	}
+	if lastErr != nil {
+		return 0, fmt.Errorf("거래 확인 상태 조회 실패: %w", lastErr)
+	}
+	return 0, fmt.Errorf("거래 확인 상태를 찾을 수 없습니다: %s", txid)
+}
+
+// Broadcast 거래 브로드캐스트
+func (e *electrumBackend) Broadcast(txHex string) (string, error) {
+	var txid string
+	if err := e.call(&txid, "blockchain.transaction.broadcast", txHex); err != nil {
+		return "", fmt.Errorf("브로드캐스트 오류: %w", err)
+	}
+	return txid, nil
+}
+
+// FeeEstimates 확인 목표 블록 수별 예상 수수료율 조회 (BTC/kB를 sat/vB로 변환)
+func (e *electrumBackend) FeeEstimates() (map[int]float64, error) {
+	estimates := make(map[int]float64)
+	for _, target := range []int{1, 2, 3, 6, 12, 24, 144} {
+		var btcPerKB float64
+		if err := e.call(&btcPerKB, "blockchain.estimatefee", target); err != nil {
+			return nil, fmt.Errorf("수수료율 조회 실패: %w", err)
+		}
+		// 서버가 추정할 수 없으면 -1 반환
+		if btcPerKB <= 0 {
+			continue
+		}
+		estimates[target] = math.Round(btcPerKB*1e8/1000*1000) / 1000
+	}
+	return estimates, nil
+}
+
+// TipHeight 최신 블록 높이 조회
+func (e *electrumBackend) TipHeight() (int64, error) {
+	var tip struct {
+		Height int64 `json:"height"`
+	}
+	if err := e.call(&tip, "blockchain.headers.subscribe"); err != nil {
+		return 0, fmt.Errorf("블록 높이 조회 실패: %w", err)
+	}
+	return tip.Height, nil
+}
+
+// blockchainIsCoinbase 코인베이스 입력 여부
+func blockchainIsCoinbase(txIn *wire.TxIn) bool {
+	return txIn.PreviousOutPoint.Index == wire.MaxPrevOutIndex && txIn.PreviousOutPoint.Hash == (chainhash.Hash{})
+}
+
+// txOutputFromWire wire 출력을 API 출력 형식으로 변환
+func txOutputFromWire(txOut *wire.TxOut) TxOutput {
+	output := TxOutput{
+		ScriptPubKey: hex.EncodeToString(txOut.PkScript),
+		Value:        txOut.Value,
+	}
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(txOut.PkScript, &chaincfg.MainNetParams)
+	if err == nil && len(addrs) == 1 {
+		output.ScriptPubKeyAddress = addrs[0].EncodeAddress()
+	}
+	return output
+}
+
+// txDetailsFromMsgTx 원시 거래를 API 거래 세부정보 형식으로 변환 (이전 출력, 수수료, 상태 제외)
+func txDetailsFromMsgTx(tx *wire.MsgTx) *TxDetails {
+	details := &TxDetails{
+		TxID:     tx.TxHash().String(),
+		Version:  tx.Version,
+		Locktime: tx.LockTime,
+		Size:     int64(tx.SerializeSize()),
+		Weight:   int64(tx.SerializeSizeStripped()*3 + tx.SerializeSize()),
+	}
+
+	for _, txIn := range tx.TxIn {
+		witness := make([]string, len(txIn.Witness))
+		for i, item := range txIn.Witness {
+			witness[i] = hex.EncodeToString(item)
+		}
+		details.Vin = append(details.Vin, TxInput{
+			TxID:     txIn.PreviousOutPoint.Hash.String(),
+			Vout:     txIn.PreviousOutPoint.Index,
+			Sequence: txIn.Sequence,
+			Witness:  witness,
+		})
+	}
+
+	for _, txOut := range tx.TxOut {
+		details.Vout = append(details.Vout, txOutputFromWire(txOut))
+	}
+
+	return details
+}
+
+// BlockHeaders startHeight부터 최대 count개의 헤더 조회
+func (e *electrumBackend) BlockHeaders(startHeight int64, count int) ([]wire.BlockHeader, error) {
+	var result struct {
+		Count int    `json:"count"`
+		Hex   string `json:"hex"`
+	}
+	if err := e.call(&result, "blockchain.block.headers", startHeight, count); err != nil {
+		return nil, fmt.Errorf("블록 헤더 조회 실패: %w", err)
+	}
+
+	raw, err := hex.DecodeString(result.Hex)
+	if err != nil || len(raw) != result.Count*wire.MaxBlockHeaderPayload {
+		return nil, fmt.Errorf("블록 헤더 디코딩 실패")
+	}
+
+	headers := make([]wire.BlockHeader, result.Count)
+	reader := bytes.NewReader(raw)
+	for i := range headers {
+		if err := headers[i].Deserialize(reader); err != nil {
+			return nil, fmt.Errorf("블록 헤더 파싱 실패: %v", err)
+		}
+	}
+	return headers, nil
+}
+
+// MerkleProof 거래의 Merkle 포함 증명 조회
+func (e *electrumBackend) MerkleProof(txid string, height int64) (*merkleProof, error) {
+	var proof merkleProof
+	if err := e.call(&proof, "blockchain.transaction.get_merkle", txid, height); err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
+
+// CoinbaseProof 블록의 코인베이스 원본 거래와 포함 증명 조회 (blockchain.transaction.id_from_pos 위치 0)
+func (e *electrumBackend) CoinbaseProof(height int64, blockHash chainhash.Hash) ([]byte, *merkleProof, error) {
+	var result struct {
+		TxHash string   `json:"tx_hash"`
+		Merkle []string `json:"merkle"`
+	}
+	if err := e.call(&result, "blockchain.transaction.id_from_pos", height, 0, true); err != nil {
+		return nil, nil, err
+	}
+
+	raw, err := e.RawTransaction(result.TxHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return raw, &merkleProof{BlockHeight: height, Merkle: result.Merkle, Pos: 0}, nil
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// electrumRequest 모의 서버가 받은 요청
+type electrumRequest struct {
+	ID     int               `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// mockElectrumServer net.Pipe로 연결되는 모의 Electrum 서버
+type mockElectrumServer struct {
+	t *testing.T
+
+	// handle 요청별 응답 (result, error 객체 중 하나)
+	handle func(req electrumRequest) (interface{}, interface{})
+	// versionDelay server.version 응답 전 대기 시간 (협상 중 다른 요청이 오는지 확인용)
+	versionDelay time.Duration
+
+	mu          sync.Mutex
+	dials       int
+	calls       map[string]int
+	earlyMethod string // 버전 응답 전에 도착한 요청 (있으면 안 됨)
+	conns       []net.Conn
+}
+
+func newMockElectrumServer(t *testing.T, handle func(req electrumRequest) (interface{}, interface{})) *mockElectrumServer {
+	server := &mockElectrumServer{t: t, handle: handle, calls: make(map[string]int)}
+	t.Cleanup(func() {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		for _, conn := range server.conns {
+			conn.Close()
+		}
+	})
+	return server
+}
+
+// dial 새 파이프 연결을 만들고 서버 쪽을 처리
+func (s *mockElectrumServer) dial() (net.Conn, error) {
+	client, server := net.Pipe()
+	s.mu.Lock()
+	s.dials++
+	s.conns = append(s.conns, client, server)
+	s.mu.Unlock()
+	go s.serve(server)
+	return client, nil
+}
+
+func (s *mockElectrumServer) serve(conn net.Conn) {
+	requests := make(chan electrumRequest, 16)
+	go func() {
+		defer close(requests)
+		reader := bufio.NewReader(conn)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if err != nil {
+				return
+			}
+			var req electrumRequest
+			if json.Unmarshal(line, &req) == nil {
+				requests <- req
+			}
+		}
+	}()
+
+	writer := bufio.NewWriter(conn)
+	reply := func(req electrumRequest) bool {
+		result, rpcErr := s.handle(req)
+		payload, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result, "error": rpcErr})
+		if _, err := writer.Write(append(payload, '\n')); err != nil {
+			return false
+		}
+		return writer.Flush() == nil
+	}
+
+	for req := range requests {
+		s.mu.Lock()
+		s.calls[req.Method]++
+		s.mu.Unlock()
+
+		if req.Method == "server.version" && s.versionDelay > 0 {
+			time.Sleep(s.versionDelay)
+			select {
+			case early, ok := <-requests:
+				if ok {
+					s.mu.Lock()
+					s.earlyMethod = early.Method
+					s.mu.Unlock()
+					if !reply(req) || !reply(early) {
+						return
+					}
+					continue
+				}
+			default:
+			}
+		}
+		if !reply(req) {
+			return
+		}
+	}
+}
+
+func (s *mockElectrumServer) count(method string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[method]
+}
+
+// defaultElectrumHandler 버전 협상과 기본 조회에 응답하는 핸들러
+func defaultElectrumHandler(req electrumRequest) (interface{}, interface{}) {
+	switch req.Method {
+	case "server.version":
+		return []string{"mock 1.0", electrumProtocolVersion}, nil
+	case "blockchain.headers.subscribe":
+		return map[string]interface{}{"height": 850000, "hex": ""}, nil
+	case "blockchain.scripthash.subscribe":
+		return "status-1", nil
+	case "blockchain.scripthash.listunspent":
+		return []electrumUTXO{
+			{TxHash: testTxID(1), TxPos: 0, Height: 849000, Value: 10_000},
+			{TxHash: testTxID(2), TxPos: 1, Height: 0, Value: 5_000},
+		}, nil
+	}
+	return nil, map[string]interface{}{"code": -32601, "message": "unknown method " + req.Method}
+}
+
+func TestElectrumHandshakeBlocksConcurrentCalls(t *testing.T) {
+	server := newMockElectrumServer(t, defaultElectrumHandler)
+	server.versionDelay = 50 * time.Millisecond
+	backend := newElectrumBackendWithDialer(context.Background(), "mock", server.dial)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			height, err := backend.TipHeight()
+			if err == nil && height != 850000 {
+				t.Errorf("TipHeight = %d, want 850000", height)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("TipHeight: %v", err)
+		}
+	}
+	server.mu.Lock()
+	early, dials := server.earlyMethod, server.dials
+	server.mu.Unlock()
+	if early != "" {
+		t.Errorf("%s was sent before server.version completed", early)
+	}
+	if dials != 1 || server.count("server.version") != 1 {
+		t.Errorf("dials = %d, version requests = %d, want a single negotiated connection",
+			dials, server.count("server.version"))
+	}
+}
+
+func TestElectrumFailedHandshakeReconnects(t *testing.T) {
+	var mu sync.Mutex
+	rejectVersion := true
+	server := newMockElectrumServer(t, func(req electrumRequest) (interface{}, interface{}) {
+		mu.Lock()
+		reject := rejectVersion
+		mu.Unlock()
+		if req.Method == "server.version" && reject {
+			return nil, map[string]interface{}{"code": 1, "message": "unsupported protocol version"}
+		}
+		return defaultElectrumHandler(req)
+	})
+	backend := newElectrumBackendWithDialer(context.Background(), "mock", server.dial)
+
+	if _, err := backend.TipHeight(); err == nil {
+		t.Fatal("TipHeight should fail when version negotiation is rejected")
+	}
+	if server.count("blockchain.headers.subscribe") != 0 {
+		t.Error("request was sent on a connection whose negotiation failed")
+	}
+
+	mu.Lock()
+	rejectVersion = false
+	mu.Unlock()
+	if height, err := backend.TipHeight(); err != nil || height != 850000 {
+		t.Fatalf("TipHeight after reconnect = %d, %v", height, err)
+	}
+	server.mu.Lock()
+	dials := server.dials
+	server.mu.Unlock()
+	if dials < 2 {
+		t.Errorf("dials = %d, want a fresh connection after the failed handshake", dials)
+	}
+}
+
+func TestElectrumAddressUTXOs(t *testing.T) {
+	server := newMockElectrumServer(t, defaultElectrumHandler)
+	backend := newElectrumBackendWithDialer(context.Background(), "mock", server.dial)
+
+	utxos, err := backend.AddressUTXOs(testWalletAddress)
+	if err != nil {
+		t.Fatalf("AddressUTXOs: %v", err)
+	}
+	if len(utxos) != 2 {
+		t.Fatalf("got %d UTXOs, want 2", len(utxos))
+	}
+	if !utxos[0].Status.Confirmed || utxos[0].Status.BlockHeight != 849000 || utxos[0].Value != 10_000 {
+		t.Errorf("confirmed UTXO = %+v", utxos[0])
+	}
+	if utxos[1].Status.Confirmed || utxos[1].Vout != 1 {
+		t.Errorf("mempool UTXO = %+v", utxos[1])
+	}
+
+	// 스크립트 해시 상태가 같으면 listunspent를 다시 요청하지 않음
+	if _, err := backend.AddressUTXOs(testWalletAddress); err != nil {
+		t.Fatalf("AddressUTXOs (cached): %v", err)
+	}
+	if got := server.count("blockchain.scripthash.listunspent"); got != 1 {
+		t.Errorf("listunspent requests = %d, want 1 (second call served from cache)", got)
+	}
+}
+
+func TestElectrumTransactionStatus(t *testing.T) {
+	walletScript := walletScriptFromState(&filterState{Address: testWalletAddress})
+	exchangeScript := []byte{0x00, 0x14, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9}
+	funding := spendingTx(wire.OutPoint{Hash: chainhash.Hash{1}}, 100_000, exchangeScript)
+	// 출력 0이 OP_RETURN인 거래
+	payment := spendingTx(wire.OutPoint{Hash: funding.TxHash(), Index: 0}, 0, []byte{txscript.OP_RETURN, 0x01, 0x2a})
+	payment.AddTxOut(&wire.TxOut{Value: 90_000, PkScript: walletScript})
+	txid := payment.TxHash().String()
+
+	rawHex := map[string]string{
+		funding.TxHash().String(): hex.EncodeToString(serializeTx(t, funding)),
+		txid:                      hex.EncodeToString(serializeTx(t, payment)),
+	}
+	header := wire.BlockHeader{Version: 4, Timestamp: time.Unix(1700000000, 0)}
+	var headerBuf bytes.Buffer
+	header.Serialize(&headerBuf)
+
+	var (
+		mu         sync.Mutex
+		walletDown bool
+		queried    []string
+	)
+	server := newMockElectrumServer(t, func(req electrumRequest) (interface{}, interface{}) {
+		switch req.Method {
+		case "blockchain.transaction.get":
+			var id string
+			json.Unmarshal(req.Params[0], &id)
+			if raw, ok := rawHex[id]; ok {
+				return raw, nil
+			}
+			return nil, map[string]interface{}{"code": 2, "message": "no such transaction"}
+		case "blockchain.scripthash.get_history":
+			var scriptHash string
+			json.Unmarshal(req.Params[0], &scriptHash)
+			mu.Lock()
+			defer mu.Unlock()
+			queried = append(queried, scriptHash)
+			switch {
+			case scriptHash == scriptHashHex(exchangeScript):
+				return nil, map[string]interface{}{"code": -32600, "message": "history too large"}
+			case scriptHash == scriptHashHex(walletScript) && !walletDown:
+				return []electrumHistory{{TxHash: txid, Height: 849000}}, nil
+			}
+			return nil, map[string]interface{}{"code": -32600, "message": "unavailable"}
+		case "blockchain.block.header":
+			return hex.EncodeToString(headerBuf.Bytes()), nil
+		}
+		return defaultElectrumHandler(req)
+	})
+	backend := newElectrumBackendWithDialer(context.Background(), "mock", server.dial)
+
+	details, err := backend.Transaction(txid)
+	if err != nil {
+		t.Fatalf("Transaction: %v", err)
+	}
+	if !details.Status.Confirmed || details.Status.BlockHeight != 849000 || details.Status.BlockTime != 1700000000 {
+		t.Errorf("status = %+v, want confirmed at 849000 from the wallet output history", details.Status)
+	}
+	for _, scriptHash := range queried {
+		if scriptHash == scriptHashHex(payment.TxOut[0].PkScript) {
+			t.Error("history of the OP_RETURN output was queried")
+		}
+	}
+
+	// 어느 스크립트로도 상태를 알 수 없으면 미확인으로 낮추지 않고 오류
+	mu.Lock()
+	walletDown = true
+	mu.Unlock()
+	if details, err := backend.Transaction(txid); err == nil {
+		t.Errorf("Transaction without any history = %+v, want an error", details.Status)
+	}
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// esploraBackend Esplora REST API 백엔드 (blockstream.info, mempool.space, 자체 서버)
+type esploraBackend struct {
+	baseURL string
+	fetcher *httpFetcher
+}
+
+// newEsploraBackend Esplora 백엔드 생성 (baseURL 예: https://blockstream.info/api)
+func newEsploraBackend(baseURL string, fetcher *httpFetcher) *esploraBackend {
+	return &esploraBackend{
+		baseURL: baseURL,
+		fetcher: fetcher,
+	}
+}
+
+// Name 백엔드 이름
+func (e *esploraBackend) Name() string {
+	return "esplora(" + e.baseURL + ")"
+}
+
+// get GET 요청 후 응답 본문 반환 (200이 아니면 오류, 일시적 오류는 재시도)
+func (e *esploraBackend) get(path string) ([]byte, error) {
+	status, body, err := e.fetcher.do(httpRequest{
+		method:     http.MethodGet,
+		url:        e.baseURL + path,
+		idempotent: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if status != 200 {
+		return nil, statusError(status, nil)
+	}
+
+	return body, nil
+}
+
+// AddressUTXOs 주소의 모든 UTXO 조회 (미확인 UTXO 포함)
+func (e *esploraBackend) AddressUTXOs(address string) ([]UTXO, error) {
+	body, err := e.get(fmt.Sprintf("/address/%s/utxo", address))
+	if err != nil {
+		return nil, fmt.Errorf("UTXO 조회 실패: %w", err)
+	}
+
+	var utxos []UTXO
+	if err := json.Unmarshal(body, &utxos); err != nil {
+		return nil, fmt.Errorf("UTXO 파싱 실패: %v", err)
+	}
+
+	return utxos, nil
+}
+
+// AddressStats 주소 통계 조회
+func (e *esploraBackend) AddressStats(address string) (*AddressStats, error) {
+	body, err := e.get(fmt.Sprintf("/address/%s", address))
+	if err != nil {
+		return nil, fmt.Errorf("주소 통계 조회 실패: %w", err)
+	}
+
+	var stats AddressStats
+	if err := json.Unmarshal(body, &stats); err != nil {
+		return nil, fmt.Errorf("주소 통계 파싱 실패: %v", err)
+	}
+
+	return &stats, nil
+}
+
+// AddressTransactions 주소의 거래 내역 한 페이지 조회 (확인된 거래는 페이지당 25개)
+func (e *esploraBackend) AddressTransactions(address, lastSeenTxID string) ([]TxDetails, error) {
+	paths := []string{fmt.Sprintf("/address/%s/txs/chain/%s", address, lastSeenTxID)}
+	if lastSeenTxID == "" {
+		paths = []string{fmt.Sprintf("/address/%s/txs/mempool", address), fmt.Sprintf("/address/%s/txs/chain", address)}
+	}
+
+	var txs []TxDetails
+	for _, path := range paths {
+		body, err := e.get(path)
+		if err != nil {
+			return nil, fmt.Errorf("거래 내역 조회 실패: %w", err)
+		}
+
+		var page []TxDetails
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, fmt.Errorf("거래 내역 파싱 실패: %v", err)
+		}
+		txs = append(txs, page...)
+	}
+
+	return txs, nil
+}
+
+// Transaction 거래 세부정보 조회
+func (e *esploraBackend) Transaction(txid string) (*TxDetails, error) {
+	body, err := e.get(fmt.Sprintf("/tx/%s", txid))
+	if err != nil {
+		return nil, fmt.Errorf("거래 세부정보 조회 실패: %w", err)
+	}
+
+	var txDetails TxDetails
+	if err := json.Unmarshal(body, &txDetails); err != nil {
+		return nil, fmt.Errorf("거래 세부정보 파싱 실패: %v", err)
+	}
+
+	return &txDetails, nil
+}
+
+// RawTransaction 직렬화된 원본 거래 조회
+func (e *esploraBackend) RawTransaction(txid string) ([]byte, error) {
+	body, err := e.get(fmt.Sprintf("/tx/%s/hex", txid))
+	if err != nil {
+		return nil, fmt.Errorf("원본 거래 조회 실패: %w", err)
+	}
+
+	raw, err := hex.DecodeString(strings.TrimSpace(string(body)))
+	if err != nil {
+		return nil, fmt.Errorf("거래 hex 디코딩 실패: %v", err)
+	}
+
+	return raw, nil
+}
+
+// Broadcast 거래 브로드캐스트
+func (e *esploraBackend) Broadcast(txHex string) (string, error) {
+	status, body, err := e.fetcher.do(httpRequest{
+		method: http.MethodPost,
+		url:    e.baseURL + "/tx",
+		body:   []byte(txHex),
+		prepare: func(req *http.Request) error {
+			req.Header.Set("Content-Type", "text/plain")
+			return nil
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("거래 브로드캐스트 실패: %w", err)
+	}
+
+	if status != 200 {
+		if status == http.StatusTooManyRequests || status >= 500 {
+			return "", fmt.Errorf("브로드캐스트 오류: %w", statusError(status, body))
+		}
+		return "", fmt.Errorf("브로드캐스트 오류: %s", string(body))
+	}
+
+	return strings.TrimSpace(string(body)), nil
+}
+
+// FeeEstimates 확인 목표 블록 수별 예상 수수료율 조회
+func (e *esploraBackend) FeeEstimates() (map[int]float64, error) {
+	body, err := e.get("/fee-estimates")
+	if err != nil {
+		return nil, fmt.Errorf("수수료율 조회 실패: %w", err)
+	}
+
+	var raw map[string]float64
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, fmt.Errorf("수수료율 파싱 실패: %v", err)
+	}
+
+	estimates := make(map[int]float64, len(raw))
+	for target, rate := range raw {
+		blocks, err := strconv.Atoi(target)
+		if err != nil {
+			continue
+		}
+		estimates[blocks] = rate
+	}
+
+	return estimates, nil
+}
+
+// TipHeight 최신 블록 높이 조회
+func (e *esploraBackend) TipHeight() (int64, error) {
+	body, err := e.get("/blocks/tip/height")
+	if err != nil {
+		return 0, fmt.Errorf("블록 높이 조회 실패: %w", err)
+	}
+
+	height, err := strconv.ParseInt(strings.TrimSpace(string(body)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("블록 높이 파싱 실패: %v", err)
+	}
+
+	return height, nil
+}
+
+// esploraBlock /blocks 응답의 블록 요약
+type esploraBlock struct {
+	ID                string `json:"id"`
+	Height            int64  `json:"height"`
+	Version           int32  `json:"version"`
+	Timestamp         int64  `json:"timestamp"`
+	Bits              uint32 `json:"bits"`
+	Nonce             uint32 `json:"nonce"`
+	MerkleRoot        string `json:"merkle_root"`
+	PreviousBlockHash string `json:"previousblockhash"`
+}
+
+// BlockHeaders startHeight부터 최대 count개의 헤더 조회 (/blocks는 요청 높이부터 아래로 10개씩 반환)
+func (e *esploraBackend) BlockHeaders(startHeight int64, count int) ([]wire.BlockHeader, error) {
+	endHeight := startHeight + int64(count) - 1
+	headers := make([]wire.BlockHeader, 0, count)
+
+	for next := startHeight; next <= endHeight; {
+		top := next + 9
+		if top > endHeight {
+			top = endHeight
+		}
+
+		body, err := e.get(fmt.Sprintf("/blocks/%d", top))
+		if err != nil {
+			return nil, fmt.Errorf("블록 헤더 조회 실패: %w", err)
+		}
+
+		var blocks []esploraBlock
+		if err := json.Unmarshal(body, &blocks); err != nil {
+			return nil, fmt.Errorf("블록 헤더 파싱 실패: %v", err)
+		}
+		if len(blocks) == 0 {
+			break
+		}
+
+		batch := make([]wire.BlockHeader, 0, len(blocks))
+		for i := len(blocks) - 1; i >= 0; i-- {
+			block := blocks[i]
+			if block.Height < next || block.Height > top {
+				continue
+			}
+			header, err := block.header()
+			if err != nil {
+				return nil, err
+			}
+			batch = append(batch, *header)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		headers = append(headers, batch...)
+		next += int64(len(batch))
+	}
+
+	return headers, nil
+}
+
+// header 블록 요약을 헤더로 변환 (계산한 해시가 블록 ID와 같은지 확인)
+func (b *esploraBlock) header() (*wire.BlockHeader, error) {
+	prevBlock := &chainhash.Hash{}
+	if b.PreviousBlockHash != "" {
+		hash, err := chainhash.NewHashFromStr(b.PreviousBlockHash)
+		if err != nil {
+			return nil, fmt.Errorf("블록 헤더 파싱 실패: %v", err)
+		}
+		prevBlock = hash
+	}
+	merkleRoot, err := chainhash.NewHashFromStr(b.MerkleRoot)
+	if err != nil {
+		return nil, fmt.Errorf("블록 헤더 파싱 실패: %v", err)
+	}
+
+	header := wire.NewBlockHeader(b.Version, prevBlock, merkleRoot, b.Bits, b.Nonce)
+	header.Timestamp = time.Unix(b.Timestamp, 0)
+	if header.BlockHash().String() != b.ID {
+		return nil, fmt.Errorf("%w: 높이 %d의 블록 해시가 헤더와 다릅니다", errSPVVerification, b.Height)
+	}
+	return header, nil
+}
+
+// MerkleProof 거래의 Merkle 포함 증명 조회
+func (e *esploraBackend) MerkleProof(txid string, height int64) (*merkleProof, error) {
+	body, err := e.get(fmt.Sprintf("/tx/%s/merkle-proof", txid))
+	if err != nil {
+		return nil, err
+	}
+
+	var proof merkleProof
+	if err := json.Unmarshal(body, &proof); err != nil {
+		return nil, fmt.Errorf("Merkle 증명 파싱 실패: %v", err)
+	}
+	return &proof, nil
+}
+
+// CoinbaseProof 블록의 코인베이스 원본 거래와 포함 증명 조회
+func (e *esploraBackend) CoinbaseProof(height int64, blockHash chainhash.Hash) ([]byte, *merkleProof, error) {
+	body, err := e.get(fmt.Sprintf("/block/%s/txid/0", blockHash))
+	if err != nil {
+		return nil, nil, err
+	}
+	txid := strings.TrimSpace(string(body))
+
+	raw, err := e.RawTransaction(txid)
+	if err != nil {
+		return nil, nil, err
+	}
+	proof, err := e.MerkleProof(txid, height)
+	if err != nil {
+		return nil, nil, err
+	}
+	return raw, proof, nil
+}
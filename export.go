@@ -0,0 +1,403 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 거래 내역 내보내기 형식
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+	ExportFormatOFX  = "ofx"
+)
+
+// ofxNameMaxLength OFX NAME 필드 최대 길이
+const ofxNameMaxLength = 32
+
+// ExportHistoryRequest 거래 내역 내보내기 요청 구조체
+type ExportHistoryRequest struct {
+	Address   string   `json:"address"`   // 지갑 주소
+	Addresses []string `json:"addresses"` // 추가 지갑 주소 (HD 지갑의 다른 주소, 선택)
+	Format    string   `json:"format"`    // 내보내기 형식 (csv, json, ofx)
+	ExportDir string   `json:"exportDir"` // 저장 폴더 (SelectSaveDirectory로 선택, 비어있으면 기본 경로)
+
+	// 라벨과 주소록 이름을 함께 내보낼 지갑 파일 (선택)
+	WalletFilePath string `json:"walletFilePath,omitempty"` // 지갑 파일 경로
+	WalletPassword string `json:"walletPassword,omitempty"` // 지갑 비밀번호
+}
+
+// ExportHistoryResponse 거래 내역 내보내기 응답 구조체
+type ExportHistoryResponse struct {
+	Success   bool   `json:"success"`   // 성공 여부
+	Message   string `json:"message"`   // 응답 메시지
+	ErrorCode string `json:"errorCode"` // 에러 코드 (다국어 처리용)
+	FilePath  string `json:"filePath"`  // 저장된 파일 경로
+	Count     int    `json:"count"`     // 내보낸 거래 수
+}
+
+// historyRecord 내보내기 파일의 거래 한 줄 (오래된 거래부터)
+type historyRecord struct {
+	Date              string `json:"date"`              // 확인 시간 (UTC, RFC3339, 미확인이면 빈 값)
+	TxID              string `json:"txid"`              // 거래 ID
+	AmountSat         int64  `json:"amountSat"`         // 지갑 잔액 변화 (satoshi)
+	AmountBTC         string `json:"amountBtc"`         // 지갑 잔액 변화 (BTC)
+	FeeSat            int64  `json:"feeSat"`            // 지갑이 낸 수수료 (satoshi)
+	FeeBTC            string `json:"feeBtc"`            // 지갑이 낸 수수료 (BTC)
+	Label             string `json:"label"`             // 거래 라벨
+	Counterparty      string `json:"counterparty"`      // 상대방 주소 (여러 개면 ; 로 구분)
+	CounterpartyLabel string `json:"counterpartyLabel"` // 상대방 주소의 라벨 또는 주소록 이름 (주소와 같은 순서로 ; 로 구분)
+	BalanceSat        int64  `json:"balanceSat"`        // 이 거래 이후 잔액 (satoshi)
+	BalanceBTC        string `json:"balanceBtc"`        // 이 거래 이후 잔액 (BTC)
+	BlockHeight       int64  `json:"blockHeight"`       // 확인된 블록 높이 (미확인이면 0)
+	Confirmations     int64  `json:"confirmations"`     // 확인 수
+}
+
+// historyLabels 내보내기에 붙일 거래 라벨과 주소 이름
+type historyLabels struct {
+	txs       map[string]string // 거래 ID별 라벨
+	addresses map[string]string // 주소별 라벨 (없으면 주소록 이름)
+}
+
+// loadHistoryLabels 지갑의 라벨 저장소와 주소록에서 거래 라벨과 주소 이름을 모음 (지갑 파일이 없으면 빈 목록)
+func (a *App) loadHistoryLabels(walletPath, password string) (*historyLabels, error) {
+	result := &historyLabels{txs: make(map[string]string), addresses: make(map[string]string)}
+	if walletPath == "" {
+		return result, nil
+	}
+
+	labels, err := a.loadLabels(walletPath, password)
+	if err != nil {
+		return nil, err
+	}
+	contacts, err := loadAddressBook(walletPath, password)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, contact := range contacts {
+		result.addresses[contact.Address] = contact.Name
+	}
+	for _, label := range labels {
+		if label.Label == "" {
+			continue
+		}
+		switch label.Type {
+		case LabelTypeTx:
+			result.txs[label.Ref] = label.Label
+		case LabelTypeAddr:
+			result.addresses[label.Ref] = label.Label
+		}
+	}
+	return result, nil
+}
+
+// counterpartyNames 상대방 주소별 이름 (주소와 같은 순서, 이름이 하나도 없으면 nil)
+func (l *historyLabels) counterpartyNames(addresses []string) []string {
+	names := make([]string, len(addresses))
+	found := false
+	for i, address := range addresses {
+		names[i] = l.addresses[address]
+		found = found || names[i] != ""
+	}
+	if !found {
+		return nil
+	}
+	return names
+}
+
+// newHistoryRecord 거래 내역 항목을 내보내기 형식으로 변환
+func newHistoryRecord(item TransactionHistoryItem, labels *historyLabels) historyRecord {
+	record := historyRecord{
+		TxID:              item.TxID,
+		AmountSat:         item.NetSat,
+		AmountBTC:         formatBTC(item.NetSat),
+		FeeSat:            item.FeeSat,
+		FeeBTC:            formatBTC(item.FeeSat),
+		Label:             labels.txs[item.TxID],
+		Counterparty:      strings.Join(item.Counterparties, ";"),
+		CounterpartyLabel: strings.Join(labels.counterpartyNames(item.Counterparties), ";"),
+		BalanceSat:        item.BalanceSat,
+		BalanceBTC:        formatBTC(item.BalanceSat),
+		BlockHeight:       item.BlockHeight,
+		Confirmations:     item.Confirmations,
+	}
+	if item.Confirmed {
+		record.Date = time.Unix(item.BlockTime, 0).UTC().Format(time.RFC3339)
+	}
+	return record
+}
+
+// formatBTC satoshi 금액을 BTC 문자열로 변환
+func formatBTC(sat int64) string {
+	formatted, _ := formatAmount(sat, UnitBTC)
+	return formatted
+}
+
+// encodeHistoryCSV CSV 형식으로 변환
+func encodeHistoryCSV(records []historyRecord) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write([]string{"date", "txid", "label", "amount_sat", "amount_btc", "fee_sat", "fee_btc",
+		"counterparty", "counterparty_label", "balance_sat", "balance_btc", "block_height", "confirmations"})
+	for _, record := range records {
+		writer.Write([]string{
+			record.Date,
+			record.TxID,
+			record.Label,
+			strconv.FormatInt(record.AmountSat, 10),
+			record.AmountBTC,
+			strconv.FormatInt(record.FeeSat, 10),
+			record.FeeBTC,
+			record.Counterparty,
+			record.CounterpartyLabel,
+			strconv.FormatInt(record.BalanceSat, 10),
+			record.BalanceBTC,
+			strconv.FormatInt(record.BlockHeight, 10),
+			strconv.FormatInt(record.Confirmations, 10),
+		})
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// encodeHistoryJSON JSON 형식으로 변환
+func encodeHistoryJSON(addresses []string, records []historyRecord) ([]byte, error) {
+	return json.MarshalIndent(struct {
+		Addresses    []string        `json:"addresses"`
+		ExportedAt   string          `json:"exportedAt"`
+		Transactions []historyRecord `json:"transactions"`
+	}{
+		Addresses:    addresses,
+		ExportedAt:   time.Now().UTC().Format(time.RFC3339),
+		Transactions: records,
+	}, "", "  ")
+}
+
+// ofxTransaction OFX STMTTRN 항목
+type ofxTransaction struct {
+	Type   string `xml:"TRNTYPE"`
+	Posted string `xml:"DTPOSTED"`
+	Amount string `xml:"TRNAMT"`
+	FITID  string `xml:"FITID"`
+	Name   string `xml:"NAME,omitempty"`
+	Memo   string `xml:"MEMO,omitempty"`
+}
+
+// ofxStatement OFX 은행 거래 명세서 (통화 XBT, 금액 BTC 단위)
+type ofxStatement struct {
+	XMLName xml.Name `xml:"OFX"`
+	Status  struct {
+		Code     int    `xml:"CODE"`
+		Severity string `xml:"SEVERITY"`
+	} `xml:"SIGNONMSGSRSV1>SONRS>STATUS"`
+	ServerDate   string           `xml:"SIGNONMSGSRSV1>SONRS>DTSERVER"`
+	Language     string           `xml:"SIGNONMSGSRSV1>SONRS>LANGUAGE"`
+	TrnUID       string           `xml:"BANKMSGSRSV1>STMTTRNRS>TRNUID"`
+	Currency     string           `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>CURDEF"`
+	BankID       string           `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>BANKACCTFROM>BANKID"`
+	AccountID    string           `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>BANKACCTFROM>ACCTID"`
+	AccountType  string           `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>BANKACCTFROM>ACCTTYPE"`
+	Start        string           `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>BANKTRANLIST>DTSTART"`
+	End          string           `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>BANKTRANLIST>DTEND"`
+	Transactions []ofxTransaction `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>BANKTRANLIST>STMTTRN"`
+	Balance      string           `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>LEDGERBAL>BALAMT"`
+	BalanceAsOf  string           `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS>LEDGERBAL>DTASOF"`
+}
+
+// ofxDate OFX 날짜 형식 (UTC)
+func ofxDate(t time.Time) string {
+	return t.UTC().Format("20060102150405") + "[0:GMT]"
+}
+
+// encodeHistoryOFX OFX 2.x 형식으로 변환 (확인된 거래만 포함, 상대방 이름이 있으면 주소 대신 사용)
+func encodeHistoryOFX(addresses []string, items []TransactionHistoryItem, labels *historyLabels) ([]byte, error) {
+	now := time.Now()
+	statement := ofxStatement{
+		ServerDate:  ofxDate(now),
+		Language:    "ENG",
+		TrnUID:      "1",
+		Currency:    "XBT",
+		BankID:      "BITCOIN",
+		AccountID:   strings.Join(addresses, ","),
+		AccountType: "CHECKING",
+		End:         ofxDate(now),
+		BalanceAsOf: ofxDate(now),
+	}
+	statement.Status.Severity = "INFO"
+
+	var balance int64
+	for i := len(items) - 1; i >= 0; i-- {
+		item := items[i]
+		if !item.Confirmed {
+			continue
+		}
+		posted := time.Unix(item.BlockTime, 0)
+		if statement.Start == "" {
+			statement.Start = ofxDate(posted)
+		}
+
+		trnType := "CREDIT"
+		if item.NetSat < 0 {
+			trnType = "DEBIT"
+		}
+		counterparties := make([]string, len(item.Counterparties))
+		for i, address := range item.Counterparties {
+			counterparties[i] = address
+			if name := labels.addresses[address]; name != "" {
+				counterparties[i] = name
+			}
+		}
+		name := truncateRunes(strings.Join(counterparties, ";"), ofxNameMaxLength)
+
+		var memo []string
+		if label := labels.txs[item.TxID]; label != "" {
+			memo = append(memo, label)
+		}
+		if item.FeeSat > 0 {
+			memo = append(memo, fmt.Sprintf("fee %s BTC", formatBTC(item.FeeSat)))
+		}
+
+		statement.Transactions = append(statement.Transactions, ofxTransaction{
+			Type:   trnType,
+			Posted: ofxDate(posted),
+			Amount: formatBTC(item.NetSat),
+			FITID:  item.TxID,
+			Name:   name,
+			Memo:   strings.Join(memo, " / "),
+		})
+		balance = item.BalanceSat
+	}
+	if statement.Start == "" {
+		statement.Start = ofxDate(now)
+	}
+	statement.Balance = formatBTC(balance)
+
+	body, err := xml.MarshalIndent(statement, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	header := xml.Header + `<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>` + "\n"
+	return append([]byte(header), body...), nil
+}
+
+// truncateRunes 문자 수 기준으로 자름 (라벨의 한글 등 다중 바이트 문자가 깨지지 않도록)
+func truncateRunes(value string, max int) string {
+	runes := []rune(value)
+	if len(runes) <= max {
+		return value
+	}
+	return string(runes[:max])
+}
+
+// uniqueFilePath 같은 이름의 파일이 있으면 번호를 붙인 경로 반환
+func uniqueFilePath(dir, name, ext string) string {
+	filePath := filepath.Join(dir, name+ext)
+	for counter := 1; ; counter++ {
+		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+			return filePath
+		}
+		filePath = filepath.Join(dir, fmt.Sprintf("%s(%d)%s", name, counter, ext))
+	}
+}
+
+// ExportTransactionHistory 지갑 거래 내역을 CSV/JSON/OFX 파일로 저장
+func (a *App) ExportTransactionHistory(request ExportHistoryRequest) ExportHistoryResponse {
+	addresses, wallet := walletAddresses(request.Address, request.Addresses)
+	if len(addresses) == 0 {
+		return ExportHistoryResponse{
+			Success: false,
+			Message: "주소를 입력해주세요",
+		}
+	}
+
+	format := strings.ToLower(request.Format)
+	if format != ExportFormatCSV && format != ExportFormatJSON && format != ExportFormatOFX {
+		return ExportHistoryResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("지원되지 않는 내보내기 형식: %s", request.Format),
+			ErrorCode: "EXPORT_FORMAT_INVALID",
+		}
+	}
+
+	// 1. 라벨과 주소록 (지갑 파일을 지정한 경우)
+	labels, err := a.loadHistoryLabels(request.WalletFilePath, request.WalletPassword)
+	if err != nil {
+		return ExportHistoryResponse{
+			Success:   false,
+			Message:   err.Error(),
+			ErrorCode: "LABEL_STORE_UNAVAILABLE",
+		}
+	}
+
+	// 2. 전체 거래 내역 조회 (잔액 누적 포함)
+	items, _, err := a.walletHistory(addresses, wallet)
+	if err != nil {
+		return ExportHistoryResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("거래 내역 조회 실패: %v", err),
+			ErrorCode: backendErrorCode(err),
+		}
+	}
+
+	// 3. 형식별 변환 (오래된 거래부터)
+	records := make([]historyRecord, 0, len(items))
+	for i := len(items) - 1; i >= 0; i-- {
+		records = append(records, newHistoryRecord(items[i], labels))
+	}
+
+	var data []byte
+	switch format {
+	case ExportFormatCSV:
+		data, err = encodeHistoryCSV(records)
+	case ExportFormatJSON:
+		data, err = encodeHistoryJSON(addresses, records)
+	case ExportFormatOFX:
+		data, err = encodeHistoryOFX(addresses, items, labels)
+		// OFX에는 확인된 거래만 포함
+		count := 0
+		for _, item := range items {
+			if item.Confirmed {
+				count++
+			}
+		}
+		records = records[:count]
+	}
+	if err != nil {
+		return ExportHistoryResponse{
+			Success: false,
+			Message: fmt.Sprintf("거래 내역 변환 실패: %v", err),
+		}
+	}
+
+	// 4. 선택한 폴더에 저장
+	saveDir := request.ExportDir
+	if saveDir == "" {
+		saveDir = defaultSaveDirectory()
+	}
+	name := fmt.Sprintf("history-%s-%s", addresses[0], time.Now().Format("20060102"))
+	filePath := uniqueFilePath(saveDir, name, "."+format)
+	if err := os.WriteFile(filePath, data, 0600); err != nil {
+		return ExportHistoryResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("파일 저장 실패: %v", err),
+			ErrorCode: "EXPORT_WRITE_FAILED",
+		}
+	}
+
+	return ExportHistoryResponse{
+		Success:  true,
+		Message:  "거래 내역을 저장했습니다",
+		FilePath: filePath,
+		Count:    len(records),
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTestWallet 비밀번호로 암호화한 테스트 지갑 파일 작성
+func writeTestWallet(t *testing.T, password string) string {
+	t.Helper()
+	data, err := encryptColdWalletData([]byte(`{"address":"`+testWalletAddress+`"}`), password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "wallet.dat")
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestExportHistoryLabels(t *testing.T) {
+	const password = "correct horse"
+	walletPath := writeTestWallet(t, password)
+	unknownAddress := "bc1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3qccfmv3"
+
+	labels := map[string]WalletLabel{}
+	for _, label := range []WalletLabel{
+		{Type: LabelTypeTx, Ref: testTxID(1), Label: "월세"},
+		{Type: LabelTypeAddr, Ref: testWalletAddress, Label: "내 지갑"},
+	} {
+		labels[labelKey(label)] = label
+	}
+	if err := saveLabels(walletPath, password, labels); err != nil {
+		t.Fatal(err)
+	}
+	if err := saveAddressBook(walletPath, password, []Contact{{ID: "1", Name: "Landlord", Address: testRecipientAddress}}); err != nil {
+		t.Fatal(err)
+	}
+
+	app := newTestApp(newFakeBackend())
+	if _, err := app.loadHistoryLabels(walletPath, "wrong"); err == nil {
+		t.Error("loadHistoryLabels with a wrong password should fail")
+	}
+	resolved, err := app.loadHistoryLabels(walletPath, password)
+	if err != nil {
+		t.Fatalf("loadHistoryLabels: %v", err)
+	}
+
+	record := newHistoryRecord(TransactionHistoryItem{
+		TxID:           testTxID(1),
+		NetSat:         -50_000,
+		Counterparties: []string{unknownAddress, testRecipientAddress},
+	}, resolved)
+	if record.Label != "월세" {
+		t.Errorf("Label = %q, want the tx label", record.Label)
+	}
+	if record.CounterpartyLabel != ";Landlord" {
+		t.Errorf("CounterpartyLabel = %q, want names aligned with %q", record.CounterpartyLabel, record.Counterparty)
+	}
+
+	unlabeled := newHistoryRecord(TransactionHistoryItem{TxID: testTxID(2), Counterparties: []string{unknownAddress}}, resolved)
+	if unlabeled.Label != "" || unlabeled.CounterpartyLabel != "" {
+		t.Errorf("unlabeled record = %+v, want empty labels", unlabeled)
+	}
+
+	data, err := encodeHistoryCSV([]historyRecord{record})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	if err != nil || len(rows) != 2 {
+		t.Fatalf("CSV rows = %v, %v", rows, err)
+	}
+	columns := make(map[string]string)
+	for i, name := range rows[0] {
+		columns[name] = rows[1][i]
+	}
+	if columns["label"] != "월세" || columns["counterparty_label"] != ";Landlord" {
+		t.Errorf("CSV label columns = %q / %q", columns["label"], columns["counterparty_label"])
+	}
+
+	// 지갑 파일을 지정하지 않으면 라벨 없이 내보냄
+	empty, err := app.loadHistoryLabels("", "")
+	if err != nil || len(empty.txs) != 0 || len(empty.addresses) != 0 {
+		t.Errorf("loadHistoryLabels without wallet = %+v, %v", empty, err)
+	}
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// defaultHistoryLimit 한 번에 반환할 기본 거래 수
+	defaultHistoryLimit = 50
+
+	// historyMaxPages 주소별 최대 조회 페이지 수 (Esplora 기준 페이지당 25개)
+	historyMaxPages = 200
+)
+
+// GetTransactionHistoryRequest 거래 내역 조회 요청 구조체
+type GetTransactionHistoryRequest struct {
+	Address   string   `json:"address"`   // 지갑 주소
+	Addresses []string `json:"addresses"` // 추가 지갑 주소 (HD 지갑의 다른 주소, 선택)
+	Offset    int      `json:"offset"`    // 건너뛸 거래 수 (최신순)
+	Limit     int      `json:"limit"`     // 반환할 거래 수 (0이면 50)
+}
+
+// TransactionHistoryItem 지갑 기준 거래 내역 항목
+type TransactionHistoryItem struct {
+	TxID           string   `json:"txid"`           // 거래 ID
+	NetSat         int64    `json:"netSat"`         // 지갑 잔액 변화 (받은 금액 - 보낸 금액, satoshi)
+	ReceivedSat    int64    `json:"receivedSat"`    // 지갑 주소로 받은 출력 합계 (satoshi)
+	SentSat        int64    `json:"sentSat"`        // 지갑 주소에서 사용한 입력 합계 (satoshi)
+	FeeSat         int64    `json:"feeSat"`         // 지갑이 낸 수수료 (지갑 입력이 없으면 0)
+	Confirmed      bool     `json:"confirmed"`      // 확인 여부
+	BlockHeight    int64    `json:"blockHeight"`    // 확인된 블록 높이
+	BlockTime      int64    `json:"blockTime"`      // 확인된 블록 시간 (Unix 초)
+	Confirmations  int64    `json:"confirmations"`  // 확인 수 (미확인이면 0)
+	BalanceSat     int64    `json:"balanceSat"`     // 이 거래 이후 지갑 잔액 (전체 내역 기준, satoshi)
+	Counterparties []string `json:"counterparties"` // 상대방 주소 (보낸 거래는 받는 주소, 받은 거래는 보낸 주소)
+}
+
+// GetTransactionHistoryResponse 거래 내역 조회 응답 구조체
+type GetTransactionHistoryResponse struct {
+	Success       bool                     `json:"success"`       // 성공 여부
+	Message       string                   `json:"message"`       // 응답 메시지
+	ErrorCode     string                   `json:"errorCode"`     // 에러 코드 (다국어 처리용)
+	Transactions  []TransactionHistoryItem `json:"transactions"`  // 거래 내역 (최신순)
+	Total         int                      `json:"total"`         // 전체 거래 수
+	TipHeight     int64                    `json:"tipHeight"`     // 확인 수 계산에 사용한 최신 블록 높이
+	Discrepancies []QuorumDiscrepancy      `json:"discrepancies"` // 교차 검증 불일치 항목 (교차 검증 모드)
+}
+
+// sortTxsNewestFirst 미확인 거래를 먼저, 확인된 거래는 블록 높이 내림차순으로 정렬
+func sortTxsNewestFirst(txs []TxDetails) {
+	sort.SliceStable(txs, func(i, j int) bool {
+		if txs[i].Status.Confirmed != txs[j].Status.Confirmed {
+			return !txs[i].Status.Confirmed
+		}
+		return txs[i].Status.BlockHeight > txs[j].Status.BlockHeight
+	})
+}
+
+// transactionsByID 거래 ID 목록의 세부정보를 동시에 조회하여 최신순으로 반환
+func transactionsByID(txids []string, fetch func(string) (*TxDetails, error)) ([]TxDetails, error) {
+	results, err := fetchConcurrently(txids, fetch)
+	if err != nil {
+		return nil, fmt.Errorf("거래 내역 조회 실패: %w", err)
+	}
+
+	txs := make([]TxDetails, 0, len(results))
+	for _, details := range results {
+		txs = append(txs, *details)
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].TxID < txs[j].TxID })
+	sortTxsNewestFirst(txs)
+	return txs, nil
+}
+
+// fetchAddressHistory 주소의 모든 거래를 페이지 단위로 조회
+func (a *App) fetchAddressHistory(address string) ([]TxDetails, error) {
+	var all []TxDetails
+	lastSeen := ""
+	for page := 0; page < historyMaxPages; page++ {
+		txs, err := a.chain().AddressTransactions(address, lastSeen)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, txs...)
+
+		// 다음 페이지는 마지막 확인된 거래 다음부터 (확인된 거래가 없으면 끝)
+		next := ""
+		for i := len(txs) - 1; i >= 0; i-- {
+			if txs[i].Status.Confirmed {
+				next = txs[i].TxID
+				break
+			}
+		}
+		if next == "" || next == lastSeen {
+			break
+		}
+		lastSeen = next
+	}
+	return all, nil
+}
+
+// historyItem 거래에서 지갑 주소 기준 입출금 계산
+func historyItem(tx *TxDetails, wallet map[string]bool, tipHeight int64) TransactionHistoryItem {
+	item := TransactionHistoryItem{
+		TxID:        tx.TxID,
+		Confirmed:   tx.Status.Confirmed,
+		BlockHeight: tx.Status.BlockHeight,
+		BlockTime:   tx.Status.BlockTime,
+	}
+
+	for _, output := range tx.Vout {
+		if wallet[output.ScriptPubKeyAddress] {
+			item.ReceivedSat += output.Value
+		}
+	}
+
+	spentFromWallet := false
+	for _, input := range tx.Vin {
+		if input.Prevout != nil && wallet[input.Prevout.ScriptPubKeyAddress] {
+			item.SentSat += input.Prevout.Value
+			spentFromWallet = true
+		}
+	}
+	if spentFromWallet {
+		item.FeeSat = tx.Fee
+	}
+
+	// 상대방: 지갑에서 보냈으면 지갑 밖으로 나간 출력, 받았으면 지갑 밖의 입력
+	seen := make(map[string]bool)
+	addCounterparty := func(address string) {
+		if address != "" && !wallet[address] && !seen[address] {
+			seen[address] = true
+			item.Counterparties = append(item.Counterparties, address)
+		}
+	}
+	if spentFromWallet {
+		for _, output := range tx.Vout {
+			addCounterparty(output.ScriptPubKeyAddress)
+		}
+	} else {
+		for _, input := range tx.Vin {
+			if input.Prevout != nil {
+				addCounterparty(input.Prevout.ScriptPubKeyAddress)
+			}
+		}
+	}
+
+	item.NetSat = item.ReceivedSat - item.SentSat
+	if tx.Status.Confirmed && tipHeight >= tx.Status.BlockHeight {
+		item.Confirmations = tipHeight - tx.Status.BlockHeight + 1
+	}
+	return item
+}
+
+// walletAddresses 요청의 지갑 주소 목록 (빈 값과 중복 제거)
+func walletAddresses(address string, extra []string) ([]string, map[string]bool) {
+	wallet := make(map[string]bool, len(extra)+1)
+	var unique []string
+	for _, addr := range append([]string{address}, extra...) {
+		if addr != "" && !wallet[addr] {
+			wallet[addr] = true
+			unique = append(unique, addr)
+		}
+	}
+	return unique, wallet
+}
+
+// walletHistory 지갑 주소들의 전체 거래 내역을 최신순으로 계산 (주소 간 중복 거래는 하나로 합침)
+func (a *App) walletHistory(addresses []string, wallet map[string]bool) ([]TransactionHistoryItem, int64, error) {
+	// 1. 주소별 거래 조회 후 txid로 병합
+	txs := make(map[string]TxDetails)
+	for _, address := range addresses {
+		history, err := a.fetchAddressHistory(address)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, tx := range history {
+			txs[tx.TxID] = tx
+		}
+	}
+
+	merged := make([]TxDetails, 0, len(txs))
+	for _, tx := range txs {
+		merged = append(merged, tx)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].TxID < merged[j].TxID })
+	sortTxsNewestFirst(merged)
+
+	// 2. 확인 수 계산용 최신 블록 높이
+	tipHeight, err := a.chain().TipHeight()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 3. 오래된 거래부터 잔액 누적
+	items := make([]TransactionHistoryItem, len(merged))
+	var balance int64
+	for i := len(merged) - 1; i >= 0; i-- {
+		items[i] = historyItem(&merged[i], wallet, tipHeight)
+		balance += items[i].NetSat
+		items[i].BalanceSat = balance
+	}
+	return items, tipHeight, nil
+}
+
+// GetTransactionHistory 지갑 주소들의 거래 내역 조회 (최신순, Offset/Limit 범위만 반환)
+func (a *App) GetTransactionHistory(request GetTransactionHistoryRequest) GetTransactionHistoryResponse {
+	addresses, wallet := walletAddresses(request.Address, request.Addresses)
+	if len(addresses) == 0 {
+		return GetTransactionHistoryResponse{
+			Success: false,
+			Message: "주소를 입력해주세요",
+		}
+	}
+
+	items, tipHeight, err := a.walletHistory(addresses, wallet)
+	if err != nil {
+		return GetTransactionHistoryResponse{
+			Success:       false,
+			Message:       fmt.Sprintf("거래 내역 조회 실패: %v", err),
+			ErrorCode:     backendErrorCode(err),
+			Discrepancies: quorumDiscrepancies(err),
+		}
+	}
+
+	limit := request.Limit
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	start := request.Offset
+	if start < 0 {
+		start = 0
+	}
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+
+	return GetTransactionHistoryResponse{
+		Success:      true,
+		Message:      "거래 내역 조회 성공",
+		Transactions: items[start:end],
+		Total:        len(items),
+		TipHeight:    tipHeight,
+	}
+}
@@ -0,0 +1,431 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// BIP329 라벨 종류
+const (
+	LabelTypeTx     = "tx"
+	LabelTypeAddr   = "addr"
+	LabelTypePubKey = "pubkey"
+	LabelTypeInput  = "input"
+	LabelTypeOutput = "output"
+	LabelTypeXPub   = "xpub"
+)
+
+const (
+	// labelFileExt 지갑 파일 옆에 저장하는 암호화된 라벨 파일 확장자
+	labelFileExt = ".labels"
+
+	// labelMaxLength BIP329 권장 라벨 최대 길이 (문자 수)
+	labelMaxLength = 255
+)
+
+// WalletLabel BIP329 라벨 레코드
+type WalletLabel struct {
+	Type      string `json:"type"`                // 라벨 종류 (tx, addr, pubkey, input, output, xpub)
+	Ref       string `json:"ref"`                 // 대상 (txid, 주소, txid:vout 등)
+	Label     string `json:"label,omitempty"`     // 라벨
+	Origin    string `json:"origin,omitempty"`    // 키 출처 디스크립터 (선택)
+	Spendable *bool  `json:"spendable,omitempty"` // 출력 사용 가능 여부 (output만, 선택)
+
+	// Extra 이 지갑이 쓰지 않는 필드 (height, fee 등 다른 지갑의 확장 필드를 가져오기/내보내기 시 그대로 유지)
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// walletLabelFields WalletLabel의 JSON 필드 (Extra 제외)
+type walletLabelFields WalletLabel
+
+// UnmarshalJSON 알려진 필드는 구조체로, 나머지는 Extra로 읽음
+func (l *WalletLabel) UnmarshalJSON(data []byte) error {
+	var fields walletLabelFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(data, &all); err != nil {
+		return err
+	}
+	for _, key := range []string{"type", "ref", "label", "origin", "spendable"} {
+		delete(all, key)
+	}
+
+	*l = WalletLabel(fields)
+	l.Extra = nil
+	if len(all) > 0 {
+		l.Extra = all
+	}
+	return nil
+}
+
+// MarshalJSON 알려진 필드와 Extra를 한 객체로 씀 (같은 이름이면 알려진 필드 우선)
+func (l WalletLabel) MarshalJSON() ([]byte, error) {
+	known, err := json.Marshal(walletLabelFields(l))
+	if err != nil || len(l.Extra) == 0 {
+		return known, err
+	}
+
+	merged := make(map[string]json.RawMessage, len(l.Extra)+5)
+	for key, value := range l.Extra {
+		merged[key] = value
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(known, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	return json.Marshal(merged)
+}
+
+// LabelStoreRequest 라벨 저장소 요청 구조체 (지갑 파일과 비밀번호로 라벨 파일을 암호화/복호화)
+type LabelStoreRequest struct {
+	FilePath string `json:"filePath"` // 지갑 파일 경로
+	Password string `json:"password"` // 지갑 비밀번호
+}
+
+// SetLabelRequest 라벨 설정 요청 구조체
+type SetLabelRequest struct {
+	FilePath string      `json:"filePath"` // 지갑 파일 경로
+	Password string      `json:"password"` // 지갑 비밀번호
+	Label    WalletLabel `json:"label"`    // 설정할 라벨 (라벨과 spendable이 모두 비어있으면 삭제)
+}
+
+// ImportLabelsRequest BIP329 JSONL 가져오기 요청 구조체
+type ImportLabelsRequest struct {
+	FilePath   string `json:"filePath"`   // 지갑 파일 경로
+	Password   string `json:"password"`   // 지갑 비밀번호
+	ImportPath string `json:"importPath"` // 가져올 JSONL 파일 경로
+}
+
+// ExportLabelsRequest BIP329 JSONL 내보내기 요청 구조체
+type ExportLabelsRequest struct {
+	FilePath  string `json:"filePath"`  // 지갑 파일 경로
+	Password  string `json:"password"`  // 지갑 비밀번호
+	ExportDir string `json:"exportDir"` // 저장 폴더 (비어있으면 기본 경로)
+}
+
+// LabelsResponse 라벨 응답 구조체
+type LabelsResponse struct {
+	Success   bool          `json:"success"`   // 성공 여부
+	Message   string        `json:"message"`   // 응답 메시지
+	ErrorCode string        `json:"errorCode"` // 에러 코드 (다국어 처리용)
+	Labels    []WalletLabel `json:"labels"`    // 전체 라벨 목록
+	Imported  int           `json:"imported"`  // 가져온 라벨 수
+	Skipped   int           `json:"skipped"`   // 형식이 잘못되어 건너뛴 줄 수
+	FilePath  string        `json:"filePath"`  // 내보낸 파일 경로
+}
+
+// walletSidecarPath 지갑 파일 옆에 저장하는 부속 파일 경로 (확장자만 바꿈)
+func walletSidecarPath(walletPath, ext string) string {
+	return strings.TrimSuffix(walletPath, filepath.Ext(walletPath)) + ext
+}
+
+// labelKey 라벨 저장소 키 (종류와 대상이 같으면 같은 라벨)
+func labelKey(label WalletLabel) string {
+	return label.Type + "|" + label.Ref
+}
+
+// validateLabel BIP329 라벨 레코드 검증
+func validateLabel(label WalletLabel) error {
+	if len([]rune(label.Label)) > labelMaxLength {
+		return fmt.Errorf("라벨은 %d자를 넘을 수 없습니다", labelMaxLength)
+	}
+	if label.Spendable != nil && label.Type != LabelTypeOutput {
+		return fmt.Errorf("spendable은 output 라벨에만 사용할 수 있습니다")
+	}
+
+	switch label.Type {
+	case LabelTypeTx:
+		if _, err := chainhash.NewHashFromStr(label.Ref); err != nil || len(label.Ref) != chainhash.MaxHashStringSize {
+			return fmt.Errorf("잘못된 거래 ID: %s", label.Ref)
+		}
+	case LabelTypeInput, LabelTypeOutput:
+		txid, vout, ok := strings.Cut(label.Ref, ":")
+		if _, err := strconv.ParseUint(vout, 10, 32); !ok || err != nil || len(txid) != chainhash.MaxHashStringSize {
+			return fmt.Errorf("잘못된 출력 참조 (txid:vout): %s", label.Ref)
+		}
+		if _, err := chainhash.NewHashFromStr(txid); err != nil {
+			return fmt.Errorf("잘못된 출력 참조 (txid:vout): %s", label.Ref)
+		}
+	case LabelTypeAddr:
+		if _, err := btcutil.DecodeAddress(label.Ref, &chaincfg.MainNetParams); err != nil {
+			return fmt.Errorf("잘못된 주소: %s", label.Ref)
+		}
+	case LabelTypePubKey:
+		if raw, err := hex.DecodeString(label.Ref); err != nil || (len(raw) != 33 && len(raw) != 65 && len(raw) != 32) {
+			return fmt.Errorf("잘못된 공개키: %s", label.Ref)
+		}
+	case LabelTypeXPub:
+		key, err := hdkeychain.NewKeyFromString(label.Ref)
+		if err != nil {
+			return fmt.Errorf("잘못된 확장 공개키: %s", label.Ref)
+		}
+		// 확장 개인키는 라벨 파일에 평문으로 내보내질 수 있으므로 거부 (값은 메시지에 남기지 않음)
+		if key.IsPrivate() {
+			return fmt.Errorf("확장 개인키는 라벨 대상으로 사용할 수 없습니다")
+		}
+	default:
+		return fmt.Errorf("지원되지 않는 라벨 종류: %s", label.Type)
+	}
+	return nil
+}
+
+// verifyWalletPassword 지갑 파일을 복호화하여 비밀번호 확인
+// 부속 파일이 아직 없을 때도 지갑과 다른 비밀번호로 부속 파일이 생기지 않도록 먼저 확인함
+func verifyWalletPassword(walletPath, password string) error {
+	walletFile, err := os.ReadFile(walletPath)
+	if err != nil {
+		return fmt.Errorf("지갑 파일을 읽을 수 없습니다: %v", err)
+	}
+	if _, err := decryptColdWalletData(walletFile, password); err != nil {
+		return fmt.Errorf("잘못된 비밀번호이거나 손상된 지갑 파일입니다")
+	}
+	return nil
+}
+
+// loadLabels 지갑 비밀번호로 라벨 파일 복호화 (파일이 없으면 빈 목록)
+func (a *App) loadLabels(walletPath, password string) (map[string]WalletLabel, error) {
+	if err := verifyWalletPassword(walletPath, password); err != nil {
+		return nil, err
+	}
+
+	labels := make(map[string]WalletLabel)
+	fileData, err := os.ReadFile(walletSidecarPath(walletPath, labelFileExt))
+	if os.IsNotExist(err) {
+		return labels, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("라벨 파일을 읽을 수 없습니다: %v", err)
+	}
+
+	plaintext, err := decryptColdWalletData(fileData, password)
+	if err != nil {
+		return nil, fmt.Errorf("라벨 파일 복호화 실패: %v", err)
+	}
+	var records []WalletLabel
+	if err := json.Unmarshal(plaintext, &records); err != nil {
+		return nil, fmt.Errorf("라벨 파일 파싱 실패: %v", err)
+	}
+	for _, record := range records {
+		labels[labelKey(record)] = record
+	}
+	return labels, nil
+}
+
+// saveLabels 라벨을 지갑 비밀번호로 암호화하여 저장
+func saveLabels(walletPath, password string, labels map[string]WalletLabel) error {
+	plaintext, err := json.Marshal(sortedLabels(labels))
+	if err != nil {
+		return err
+	}
+	fileData, err := encryptColdWalletData(plaintext, password)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(walletSidecarPath(walletPath, labelFileExt), fileData, 0600); err != nil {
+		return fmt.Errorf("라벨 파일 저장 실패: %v", err)
+	}
+	return nil
+}
+
+// sortedLabels 종류와 대상 순으로 정렬된 라벨 목록
+func sortedLabels(labels map[string]WalletLabel) []WalletLabel {
+	list := make([]WalletLabel, 0, len(labels))
+	for _, label := range labels {
+		list = append(list, label)
+	}
+	sort.Slice(list, func(i, j int) bool { return labelKey(list[i]) < labelKey(list[j]) })
+	return list
+}
+
+// labelsFailure 라벨 저장소 오류 응답
+func labelsFailure(err error, code string) LabelsResponse {
+	return LabelsResponse{
+		Success:   false,
+		Message:   err.Error(),
+		ErrorCode: code,
+	}
+}
+
+// GetLabels 지갑의 모든 라벨 조회
+func (a *App) GetLabels(request LabelStoreRequest) LabelsResponse {
+	labels, err := a.loadLabels(request.FilePath, request.Password)
+	if err != nil {
+		return labelsFailure(err, "LABEL_STORE_UNAVAILABLE")
+	}
+
+	return LabelsResponse{
+		Success: true,
+		Message: "성공",
+		Labels:  sortedLabels(labels),
+	}
+}
+
+// SetLabel 라벨 추가/수정 (라벨과 spendable이 모두 비어있으면 삭제)
+func (a *App) SetLabel(request SetLabelRequest) LabelsResponse {
+	label := request.Label
+	label.Ref = strings.TrimSpace(label.Ref)
+	if err := validateLabel(label); err != nil {
+		return labelsFailure(err, "LABEL_INVALID")
+	}
+
+	labels, err := a.loadLabels(request.FilePath, request.Password)
+	if err != nil {
+		return labelsFailure(err, "LABEL_STORE_UNAVAILABLE")
+	}
+
+	if label.Label == "" && label.Spendable == nil {
+		delete(labels, labelKey(label))
+	} else {
+		// 화면에서 수정해도 가져온 확장 필드는 유지
+		if existing, ok := labels[labelKey(label)]; ok && label.Extra == nil {
+			label.Extra = existing.Extra
+		}
+		labels[labelKey(label)] = label
+	}
+
+	if err := saveLabels(request.FilePath, request.Password, labels); err != nil {
+		return labelsFailure(err, "LABEL_STORE_UNAVAILABLE")
+	}
+
+	return LabelsResponse{
+		Success: true,
+		Message: "라벨이 저장되었습니다",
+		Labels:  sortedLabels(labels),
+	}
+}
+
+// parseBIP329 BIP329 JSONL 파싱 (잘못된 줄은 건너뛰고 개수 반환)
+func parseBIP329(data []byte) ([]WalletLabel, int) {
+	var records []WalletLabel
+	skipped := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var record WalletLabel
+		if err := json.Unmarshal([]byte(line), &record); err != nil || validateLabel(record) != nil {
+			skipped++
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, skipped
+}
+
+// ImportLabels BIP329 JSONL 파일에서 라벨 가져오기 (같은 대상의 기존 라벨은 덮어씀)
+func (a *App) ImportLabels(request ImportLabelsRequest) LabelsResponse {
+	data, err := os.ReadFile(request.ImportPath)
+	if err != nil {
+		return labelsFailure(fmt.Errorf("라벨 파일을 읽을 수 없습니다: %v", err), "LABEL_IMPORT_FAILED")
+	}
+
+	labels, err := a.loadLabels(request.FilePath, request.Password)
+	if err != nil {
+		return labelsFailure(err, "LABEL_STORE_UNAVAILABLE")
+	}
+
+	records, skipped := parseBIP329(data)
+	for _, record := range records {
+		labels[labelKey(record)] = record
+	}
+
+	if err := saveLabels(request.FilePath, request.Password, labels); err != nil {
+		return labelsFailure(err, "LABEL_STORE_UNAVAILABLE")
+	}
+
+	return LabelsResponse{
+		Success:  true,
+		Message:  fmt.Sprintf("%d개의 라벨을 가져왔습니다", len(records)),
+		Labels:   sortedLabels(labels),
+		Imported: len(records),
+		Skipped:  skipped,
+	}
+}
+
+// ExportLabels 라벨을 BIP329 JSONL 파일로 내보내기 (암호화하지 않으므로 보관에 주의)
+func (a *App) ExportLabels(request ExportLabelsRequest) LabelsResponse {
+	labels, err := a.loadLabels(request.FilePath, request.Password)
+	if err != nil {
+		return labelsFailure(err, "LABEL_STORE_UNAVAILABLE")
+	}
+
+	var buf bytes.Buffer
+	list := sortedLabels(labels)
+	for _, label := range list {
+		line, err := json.Marshal(label)
+		if err != nil {
+			return labelsFailure(err, "LABEL_EXPORT_FAILED")
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	saveDir := request.ExportDir
+	if saveDir == "" {
+		saveDir = defaultSaveDirectory()
+	}
+	walletName := strings.TrimSuffix(filepath.Base(request.FilePath), filepath.Ext(request.FilePath))
+	filePath := uniqueFilePath(saveDir, walletName+"-labels", ".jsonl")
+	if err := os.WriteFile(filePath, buf.Bytes(), 0600); err != nil {
+		return labelsFailure(fmt.Errorf("파일 저장 실패: %v", err), "LABEL_EXPORT_FAILED")
+	}
+
+	return LabelsResponse{
+		Success:  true,
+		Message:  fmt.Sprintf("%d개의 라벨을 내보냈습니다", len(list)),
+		Labels:   list,
+		FilePath: filePath,
+	}
+}
+
+// SelectLabelsFile BIP329 라벨 파일 선택 대화상자 표시
+func (a *App) SelectLabelsFile() (string, error) {
+	selectedPath, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: "라벨 파일 선택 (BIP329)",
+		Filters: []runtime.FileFilter{
+			{
+				DisplayName: "BIP329 라벨 파일 (*.jsonl)",
+				Pattern:     "*.jsonl",
+			},
+			{
+				DisplayName: "모든 파일 (*.*)",
+				Pattern:     "*.*",
+			},
+		},
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	// 사용자가 취소를 선택한 경우
+	if selectedPath == "" {
+		return "", fmt.Errorf("파일 선택이 취소되었습니다")
+	}
+
+	return selectedPath, nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+func TestValidateLabelXPub(t *testing.T) {
+	seed := bytes.Repeat([]byte{0x42}, hdkeychain.RecommendedSeedLen)
+	master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xpub, err := master.Neuter()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := validateLabel(WalletLabel{Type: LabelTypeXPub, Ref: xpub.String(), Label: "cold"}); err != nil {
+		t.Errorf("xpub label: %v", err)
+	}
+
+	xprv := master.String()
+	err = validateLabel(WalletLabel{Type: LabelTypeXPub, Ref: xprv, Label: "cold"})
+	if err == nil {
+		t.Fatal("xprv label should be rejected")
+	}
+	if strings.Contains(err.Error(), xprv) {
+		t.Error("rejection message must not echo the private key")
+	}
+
+	records, skipped := parseBIP329([]byte(`{"type":"xpub","ref":"` + xprv + `","label":"cold"}` + "\n"))
+	if len(records) != 0 || skipped != 1 {
+		t.Errorf("parseBIP329 imported %d xprv records (skipped %d), want 0 (1)", len(records), skipped)
+	}
+}
+
+func TestBIP329PreservesUnknownFields(t *testing.T) {
+	line := `{"type":"tx","ref":"` + testTxID(1) + `","label":"rent","origin":"wpkh([d34db33f/84'/0'/0'])",` +
+		`"height":800000,"time":"2023-07-24T10:00:00Z","fee":1234,"custom":{"nested":[1,2]}}`
+	records, skipped := parseBIP329([]byte(line + "\n"))
+	if len(records) != 1 || skipped != 0 {
+		t.Fatalf("parseBIP329 = %d records, %d skipped", len(records), skipped)
+	}
+	record := records[0]
+	if record.Label != "rent" || len(record.Extra) != 4 {
+		t.Errorf("record = %+v, want label and 4 extra fields", record)
+	}
+
+	const password = "correct horse"
+	walletPath := writeTestWallet(t, password)
+	app := newTestApp(newFakeBackend())
+	if err := saveLabels(walletPath, password, map[string]WalletLabel{labelKey(record): record}); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := app.loadLabels(walletPath, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exported, err := json.Marshal(loaded[labelKey(record)])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want, got map[string]interface{}
+	json.Unmarshal([]byte(line), &want)
+	json.Unmarshal(exported, &got)
+	wantJSON, _ := json.Marshal(want)
+	gotJSON, _ := json.Marshal(got)
+	if string(wantJSON) != string(gotJSON) {
+		t.Errorf("round trip through the label store:\n got %s\nwant %s", gotJSON, wantJSON)
+	}
+
+	// 화면에서 라벨만 바꿔도 확장 필드는 유지
+	response := app.SetLabel(SetLabelRequest{
+		FilePath: walletPath,
+		Password: password,
+		Label:    WalletLabel{Type: LabelTypeTx, Ref: testTxID(1), Label: "rent (July)"},
+	})
+	if !response.Success || len(response.Labels) != 1 {
+		t.Fatalf("SetLabel: %+v", response)
+	}
+	if updated := response.Labels[0]; updated.Label != "rent (July)" || string(updated.Extra["fee"]) != "1234" {
+		t.Errorf("updated label = %+v, want new label with extra fields kept", updated)
+	}
+
+	// 확장 필드가 없으면 기존 출력 형식 그대로
+	plain, _ := json.Marshal(WalletLabel{Type: LabelTypeTx, Ref: testTxID(2), Label: "plain"})
+	if string(plain) != `{"type":"tx","ref":"`+testTxID(2)+`","label":"plain"}` {
+		t.Errorf("plain label = %s", plain)
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// 백엔드 통신 에러 코드 (다국어 처리용)
+const (
+	ErrorCodeBackendTimeout     = "BACKEND_TIMEOUT"      // 응답 시간 초과
+	ErrorCodeBackendRateLimited = "BACKEND_RATE_LIMITED" // 요청 한도 초과 (429)
+	ErrorCodeBackendServerError = "BACKEND_SERVER_ERROR" // 서버 오류 (5xx)
+	ErrorCodeBackendUnavailable = "BACKEND_UNAVAILABLE"  // 서버 연결 실패
+	ErrorCodeProxyUnavailable   = "PROXY_UNAVAILABLE"    // 프록시 필수 설정에서 프록시 연결 실패
+)
+
+const (
+	// backendRequestTimeout 요청 1회의 기본 시간 제한
+	backendRequestTimeout = 20 * time.Second
+
+	// backendMaxRetries 멱등 조회 요청의 최대 재시도 횟수
+	backendMaxRetries = 3
+
+	// backendRetryBaseDelay 첫 재시도 대기 시간 (재시도마다 2배)
+	backendRetryBaseDelay = 500 * time.Millisecond
+
+	// backendMaxRetryDelay 재시도 대기 시간 상한 (Retry-After 포함)
+	backendMaxRetryDelay = 10 * time.Second
+)
+
+// backendError 에러 코드가 붙은 백엔드 통신 오류
+type backendError struct {
+	code string
+	err  error
+}
+
+// Error 오류 메시지
+func (e *backendError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap 원인 오류
+func (e *backendError) Unwrap() error {
+	return e.err
+}
+
+// backendErrorCode 오류에 해당하는 에러 코드 (백엔드 통신 오류가 아니면 빈 문자열)
+func backendErrorCode(err error) string {
+	var backendErr *backendError
+	if errors.As(err, &backendErr) {
+		return backendErr.code
+	}
+	if quorumDiscrepancies(err) != nil {
+		return ErrorCodeBackendDiscrepancy
+	}
+	if errors.Is(err, errSPVVerification) {
+		return ErrorCodeSPVVerification
+	}
+	if errors.Is(err, errProxyUnavailable) {
+		return ErrorCodeProxyUnavailable
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrorCodeBackendTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrorCodeBackendTimeout
+	}
+	return ""
+}
+
+// isRetryableCode 잠시 후 다시 시도하면 성공할 수 있는 에러 코드인지 확인
+func isRetryableCode(code string) bool {
+	switch code {
+	case ErrorCodeBackendTimeout, ErrorCodeBackendRateLimited, ErrorCodeBackendServerError, ErrorCodeBackendUnavailable, ErrorCodeP2PUnconfirmed:
+		return true
+	}
+	return false
+}
+
+// transportError 전송 계층 오류를 에러 코드가 붙은 오류로 변환
+func transportError(err error) error {
+	if code := backendErrorCode(err); code != "" {
+		return &backendError{code: code, err: err}
+	}
+	return &backendError{code: ErrorCodeBackendUnavailable, err: err}
+}
+
+// statusError HTTP 상태 코드를 에러 코드가 붙은 오류로 변환
+func statusError(status int, body []byte) error {
+	err := fmt.Errorf("API 오류: %d", status)
+	if len(body) > 0 && len(body) <= 512 {
+		err = fmt.Errorf("API 오류: %d (%s)", status, bytes.TrimSpace(body))
+	}
+
+	switch {
+	case status == http.StatusTooManyRequests:
+		return &backendError{code: ErrorCodeBackendRateLimited, err: err}
+	case status >= 500:
+		return &backendError{code: ErrorCodeBackendServerError, err: err}
+	}
+	return err
+}
+
+// httpRequest 백엔드 HTTP 요청
+type httpRequest struct {
+	method     string
+	url        string
+	body       []byte
+	idempotent bool                      // 멱등 조회 요청 (실패 시 재시도)
+	timeout    time.Duration             // 요청 1회 시간 제한 (0이면 기본값)
+	prepare    func(*http.Request) error // 헤더 설정 등 (재시도마다 호출)
+}
+
+// httpFetcher 모든 HTTP 백엔드가 공유하는 요청 처리기
+// 요청마다 앱 컨텍스트에서 파생된 시간 제한을 적용하고, 멱등 조회는 지수 백오프로 재시도함
+type httpFetcher struct {
+	ctx    context.Context
+	client *http.Client
+}
+
+// newHTTPFetcher 요청 처리기 생성 (ctx가 취소되면 진행 중인 요청도 취소됨)
+func newHTTPFetcher(ctx context.Context, client *http.Client) *httpFetcher {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &httpFetcher{ctx: ctx, client: client}
+}
+
+// do 요청 전송 후 상태 코드와 본문 반환
+// 429와 502/503/504 응답 및 전송 오류는 멱등 요청일 때만 재시도하며, 재시도 후에도 실패한 상태 코드는 그대로 반환
+func (h *httpFetcher) do(request httpRequest) (int, []byte, error) {
+	attempts := 1
+	if request.idempotent {
+		attempts += backendMaxRetries
+	}
+
+	for attempt := 0; ; attempt++ {
+		status, body, retryAfter, err := h.once(request)
+		if err != nil {
+			err = transportError(err)
+			// 앱 종료 등으로 상위 컨텍스트가 취소되면 즉시 중단
+			if h.ctx.Err() != nil {
+				return 0, nil, err
+			}
+		} else if !retryableStatus(status) {
+			return status, body, nil
+		}
+
+		if attempt == attempts-1 {
+			return status, body, err
+		}
+
+		delay := backendRetryBaseDelay << attempt
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+		if delay > backendMaxRetryDelay {
+			delay = backendMaxRetryDelay
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-h.ctx.Done():
+			return 0, nil, transportError(h.ctx.Err())
+		}
+	}
+}
+
+// once 요청 1회 전송
+func (h *httpFetcher) once(request httpRequest) (int, []byte, time.Duration, error) {
+	timeout := request.timeout
+	if timeout == 0 {
+		timeout = backendRequestTimeout
+	}
+	ctx, cancel := context.WithTimeout(h.ctx, timeout)
+	defer cancel()
+
+	var body io.Reader
+	if request.body != nil {
+		body = bytes.NewReader(request.body)
+	}
+	req, err := http.NewRequestWithContext(ctx, request.method, request.url, body)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	if request.prepare != nil {
+		if err := request.prepare(req); err != nil {
+			return 0, nil, 0, err
+		}
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, 0, fmt.Errorf("응답 읽기 실패: %w", err)
+	}
+
+	return resp.StatusCode, respBody, parseRetryAfter(resp.Header.Get("Retry-After")), nil
+}
+
+// retryableStatus 재시도할 HTTP 상태 코드인지 확인
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter Retry-After 헤더(초 단위)를 대기 시간으로 변환
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,562 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	// P2P 브로드캐스트 방식
+	P2PModeOnly       = "only"       // P2P 노드로만 브로드캐스트
+	P2PModeAdditional = "additional" // 백엔드와 P2P 노드 모두에 브로드캐스트
+
+	// p2pUserAgent version 메시지의 사용자 에이전트
+	p2pUserAgent = "gowallet"
+
+	// p2pTimeout 피어 1곳과의 연결, 핸드셰이크, 거래 전달 전체 시간 제한
+	p2pTimeout = 30 * time.Second
+
+	// p2pGetDataWait inv 뒤 ping에 pong이 와도 getdata를 더 기다리는 시간
+	// (Bitcoin Core는 인바운드 피어에 대한 거래 요청을 2초 늦춤)
+	p2pGetDataWait = 3 * time.Second
+
+	// p2pAnnounceWait 거래를 전달한 뒤 감시 연결로 inv 알림을 기다리는 시간
+	// (Bitcoin Core는 인바운드 피어에 평균 5초 간격으로 거래를 알림)
+	p2pAnnounceWait = 20 * time.Second
+
+	// p2pProbeWait 알림이 없을 때 감시 연결의 getdata 조회에 대한 응답을 기다리는 시간
+	p2pProbeWait = 5 * time.Second
+)
+
+// ErrorCodeP2PUnconfirmed 피어에 거래를 전달했지만 멤풀에 들어갔는지 확인하지 못함
+const ErrorCodeP2PUnconfirmed = "P2P_BROADCAST_UNCONFIRMED"
+
+// P2PConfig 비트코인 P2P 노드 직접 브로드캐스트 설정
+type P2PConfig struct {
+	Enabled bool     `json:"enabled"` // P2P 브로드캐스트 사용 여부
+	Peers   []string `json:"peers"`   // 피어 주소 목록 (호스트 또는 호스트:포트, .onion은 프록시 필요)
+	Mode    string   `json:"mode"`    // only: P2P로만 전송, additional: 백엔드와 함께 전송 (기본값)
+	Network string   `json:"network"` // mainnet(기본값), testnet, signet, regtest (테스트용)
+}
+
+// p2pNetworkParams 네트워크 이름에 해당하는 체인 파라미터
+func p2pNetworkParams(network string) (*chaincfg.Params, error) {
+	switch network {
+	case "", "mainnet":
+		return &chaincfg.MainNetParams, nil
+	case "testnet":
+		return &chaincfg.TestNet3Params, nil
+	case "signet":
+		return &chaincfg.SigNetParams, nil
+	case "regtest":
+		return &chaincfg.RegressionNetParams, nil
+	}
+	return nil, fmt.Errorf("지원되지 않는 네트워크: %s", network)
+}
+
+// p2pBroadcaster 비트코인 P2P 프로토콜로 거래를 직접 전달
+// 피어마다 version/verack 핸드셰이크 후 inv와 ping을 보내고, getdata 요청에 tx로 응답한 뒤
+// 다시 ping/pong으로 피어가 거래를 처리했는지 확인함 (getdata 없이 pong만 오면 이미 아는 거래)
+// 최신 노드는 거래를 거부해도 reject를 보내지 않으므로, 거래 알림을 받는 별도 감시 연결에서
+// 피어가 거래를 inv로 다시 알려 와야 멤풀에 들어간 것으로 봄
+type p2pBroadcaster struct {
+	peers        []string
+	params       *chaincfg.Params
+	dial         dialContextFunc
+	ctx          context.Context
+	getDataWait  time.Duration
+	announceWait time.Duration
+	probeWait    time.Duration
+}
+
+// newP2PBroadcaster P2P 브로드캐스터 생성 (연결은 백엔드와 같은 프록시 설정을 따름)
+func newP2PBroadcaster(ctx context.Context, config P2PConfig, dial dialContextFunc) (*p2pBroadcaster, error) {
+	params, err := p2pNetworkParams(config.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	var peers []string
+	for _, peer := range config.Peers {
+		peer = strings.TrimSpace(peer)
+		if peer == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(peer); err != nil {
+			peer = net.JoinHostPort(peer, params.DefaultPort)
+		}
+		peers = append(peers, peer)
+	}
+	if len(peers) == 0 {
+		return nil, fmt.Errorf("P2P 피어 주소를 1개 이상 입력해주세요")
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &p2pBroadcaster{
+		peers:        peers,
+		params:       params,
+		dial:         dial,
+		ctx:          ctx,
+		getDataWait:  p2pGetDataWait,
+		announceWait: p2pAnnounceWait,
+		probeWait:    p2pProbeWait,
+	}, nil
+}
+
+// broadcast 모든 피어에 동시에 거래 전달 (한 곳 이상에 전달하고 감시 연결에서 알림을 받으면 성공)
+func (p *p2pBroadcaster) broadcast(tx *wire.MsgTx) error {
+	ctx, cancel := context.WithTimeout(p.ctx, p2pTimeout+p.announceWait+p.probeWait)
+	defer cancel()
+
+	// 거래를 전달하기 전에 감시 연결을 열어 두어야 피어가 멤풀에 넣은 뒤 보내는 알림을 받을 수 있음
+	txHash := tx.TxHash()
+	seen := make(chan bool, len(p.peers))
+	watchers := p.watch(ctx, txHash, seen)
+
+	errs := make([]error, len(p.peers))
+	var wg sync.WaitGroup
+	for i, peer := range p.peers {
+		wg.Add(1)
+		go func(i int, peer string) {
+			defer wg.Done()
+			errs[i] = p.sendToPeer(peer, tx)
+		}(i, peer)
+	}
+	wg.Wait()
+
+	var failures []string
+	var firstErr error
+	delivered := false
+	for i, err := range errs {
+		if err == nil {
+			delivered = true
+			continue
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		failures = append(failures, fmt.Sprintf("%s: %v", p.peers[i], err))
+	}
+	if !delivered {
+		return &backendError{
+			code: backendErrorCodeOr(firstErr, ErrorCodeBackendUnavailable),
+			err:  fmt.Errorf("P2P 브로드캐스트 실패 (%s)", strings.Join(failures, "; ")),
+		}
+	}
+
+	if p.confirm(watchers, txHash, seen) {
+		return nil
+	}
+	return &backendError{
+		code: ErrorCodeP2PUnconfirmed,
+		err:  fmt.Errorf("피어에 거래를 전달했지만 멤풀에 들어갔는지 확인하지 못했습니다"),
+	}
+}
+
+// p2pWatcher 거래 알림을 받는 감시 연결
+type p2pWatcher struct {
+	conn net.Conn
+	mu   sync.Mutex // 수신 고루틴의 pong과 getdata 조회가 동시에 쓰지 않도록 보호
+}
+
+// watch 피어마다 거래 알림을 받는 감시 연결을 열고 수신을 시작
+// 거래 inv나 조회한 거래가 오면 seen에 true, notfound면 false를 보냄 (연결은 ctx가 끝나면 닫힘)
+func (p *p2pBroadcaster) watch(ctx context.Context, txHash chainhash.Hash, seen chan<- bool) []*p2pWatcher {
+	watchers := make([]*p2pWatcher, len(p.peers))
+	var wg sync.WaitGroup
+	for i, peer := range p.peers {
+		wg.Add(1)
+		go func(i int, peer string) {
+			defer wg.Done()
+			dialCtx, cancel := context.WithTimeout(ctx, p2pTimeout)
+			defer cancel()
+			conn, err := p.dial(dialCtx, "tcp", peer)
+			if err != nil {
+				return
+			}
+			deadline, _ := dialCtx.Deadline()
+			conn.SetDeadline(deadline)
+			if err := p.handshake(conn, true); err != nil {
+				conn.Close()
+				return
+			}
+			conn.SetDeadline(time.Time{})
+			context.AfterFunc(ctx, func() { conn.Close() })
+			watchers[i] = &p2pWatcher{conn: conn}
+		}(i, peer)
+	}
+	wg.Wait()
+
+	var opened []*p2pWatcher
+	for _, watcher := range watchers {
+		if watcher != nil {
+			opened = append(opened, watcher)
+			go p.listen(watcher, txHash, seen)
+		}
+	}
+	return opened
+}
+
+// listen 감시 연결에서 거래 알림을 기다림
+func (p *p2pBroadcaster) listen(watcher *p2pWatcher, txHash chainhash.Hash, seen chan<- bool) {
+	for {
+		msg, err := p.read(watcher.conn)
+		if err != nil {
+			return
+		}
+
+		switch m := msg.(type) {
+		case *wire.MsgInv:
+			for _, vect := range m.InvList {
+				if vect.Hash == txHash && (vect.Type == wire.InvTypeTx || vect.Type == wire.InvTypeWitnessTx) {
+					seen <- true
+					return
+				}
+			}
+		case *wire.MsgTx:
+			if m.TxHash() == txHash {
+				seen <- true
+				return
+			}
+		case *wire.MsgNotFound:
+			for _, vect := range m.InvList {
+				if vect.Hash == txHash {
+					seen <- false
+					return
+				}
+			}
+		case *wire.MsgPing:
+			watcher.mu.Lock()
+			err := p.write(watcher.conn, wire.NewMsgPong(m.Nonce))
+			watcher.mu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+// confirm 감시 연결로 거래 알림을 기다리고, 없으면 getdata로 멤풀에 있는지 조회
+// (이미 퍼진 거래는 알림이 다시 오지 않지만 Bitcoin Core는 2분 넘게 멤풀에 있던 거래를 getdata로 돌려줌)
+func (p *p2pBroadcaster) confirm(watchers []*p2pWatcher, txHash chainhash.Hash, seen <-chan bool) bool {
+	if len(watchers) == 0 {
+		return false
+	}
+
+	// 감시 연결마다 notfound는 한 번만 오므로 모두 notfound면 더 기다리지 않음
+	notFound := 0
+	timer := time.NewTimer(p.announceWait)
+	defer timer.Stop()
+	select {
+	case ok := <-seen:
+		if ok {
+			return true
+		}
+		notFound++
+	case <-timer.C:
+	}
+
+	getData := wire.NewMsgGetData()
+	getData.AddInvVect(wire.NewInvVect(wire.InvTypeWitnessTx, &txHash))
+	for _, watcher := range watchers {
+		watcher.mu.Lock()
+		p.write(watcher.conn, getData)
+		watcher.mu.Unlock()
+	}
+
+	timer.Reset(p.probeWait)
+	for notFound < len(watchers) {
+		select {
+		case ok := <-seen:
+			if ok {
+				return true
+			}
+			notFound++
+		case <-timer.C:
+			return false
+		}
+	}
+	return false
+}
+
+// backendErrorCodeOr 오류의 에러 코드 (없으면 기본값)
+func backendErrorCodeOr(err error, fallback string) string {
+	if code := backendErrorCode(err); code != "" {
+		return code
+	}
+	return fallback
+}
+
+// sendToPeer 피어 1곳에 거래 전달
+func (p *p2pBroadcaster) sendToPeer(peer string, tx *wire.MsgTx) error {
+	ctx, cancel := context.WithTimeout(p.ctx, p2pTimeout)
+	defer cancel()
+
+	conn, err := p.dial(ctx, "tcp", peer)
+	if err != nil {
+		return transportError(err)
+	}
+	defer conn.Close()
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+
+	if err := p.handshake(conn, false); err != nil {
+		return err
+	}
+
+	// inv 바로 뒤에 ping을 보내 피어가 inv를 처리했는지 확인
+	txHash := tx.TxHash()
+	inv := wire.NewMsgInv()
+	inv.AddInvVect(wire.NewInvVect(wire.InvTypeTx, &txHash))
+	if err := p.write(conn, inv); err != nil {
+		return err
+	}
+	nonce := rand.Uint64()
+	if err := p.write(conn, wire.NewMsgPing(nonce)); err != nil {
+		return err
+	}
+
+	requested := false
+	invAcked := false
+	for {
+		msg, err := p.read(conn)
+		if err != nil {
+			// pong 이후 기다리는 동안 getdata가 없으면 피어가 이미 거래를 알고 있음 (멤풀 여부는 감시 연결에서 확인)
+			var netErr net.Error
+			if invAcked && !requested && errors.As(err, &netErr) && netErr.Timeout() && ctx.Err() == nil {
+				return nil
+			}
+			return err
+		}
+
+		switch m := msg.(type) {
+		case *wire.MsgGetData:
+			sent := false
+			for _, vect := range m.InvList {
+				if vect.Hash != txHash || (vect.Type != wire.InvTypeTx && vect.Type != wire.InvTypeWitnessTx) {
+					continue
+				}
+				if err := p.write(conn, tx); err != nil {
+					return err
+				}
+				sent = true
+			}
+			// 거래를 보낸 뒤의 ping에 pong이 오면 피어가 거래를 처리한 것
+			// (BIP61을 지원하는 옛 노드만 거부 시 reject를 보내므로 받아들였는지는 감시 연결에서 확인)
+			if sent && !requested {
+				requested = true
+				conn.SetReadDeadline(deadline)
+				nonce = rand.Uint64()
+				if err := p.write(conn, wire.NewMsgPing(nonce)); err != nil {
+					return err
+				}
+			}
+		case *wire.MsgReject:
+			if m.Hash == txHash {
+				return fmt.Errorf("피어가 거래를 거부했습니다: %s (%s)", m.Reason, m.Code)
+			}
+		case *wire.MsgPing:
+			if err := p.write(conn, wire.NewMsgPong(m.Nonce)); err != nil {
+				return err
+			}
+		case *wire.MsgPong:
+			if m.Nonce != nonce {
+				continue
+			}
+			if requested {
+				return nil
+			}
+			if !invAcked {
+				invAcked = true
+				wait := time.Now().Add(p.getDataWait)
+				if !deadline.IsZero() && deadline.Before(wait) {
+					wait = deadline
+				}
+				conn.SetReadDeadline(wait)
+			}
+		}
+	}
+}
+
+// handshake version/verack 교환 (relay가 false면 다른 거래 알림은 받지 않음)
+func (p *p2pBroadcaster) handshake(conn net.Conn, relay bool) error {
+	you := wire.NewNetAddressIPPort(net.IPv4zero, 0, 0)
+	if host, port, err := net.SplitHostPort(conn.RemoteAddr().String()); err == nil {
+		if ip := net.ParseIP(host); ip != nil {
+			portNum, _ := strconv.Atoi(port)
+			you = wire.NewNetAddressIPPort(ip, uint16(portNum), 0)
+		}
+	}
+	me := wire.NewNetAddressIPPort(net.IPv4zero, 0, 0)
+
+	version := wire.NewMsgVersion(me, you, rand.Uint64(), 0)
+	version.UserAgent = wire.DefaultUserAgent + p2pUserAgent + "/"
+	version.DisableRelayTx = !relay
+	if err := p.write(conn, version); err != nil {
+		return err
+	}
+
+	gotVersion, gotVerAck := false, false
+	for !gotVersion || !gotVerAck {
+		msg, err := p.read(conn)
+		if err != nil {
+			return err
+		}
+
+		switch m := msg.(type) {
+		case *wire.MsgVersion:
+			gotVersion = true
+			if err := p.write(conn, wire.NewMsgVerAck()); err != nil {
+				return err
+			}
+		case *wire.MsgVerAck:
+			gotVerAck = true
+		case *wire.MsgReject:
+			return fmt.Errorf("피어가 연결을 거부했습니다: %s", m.Reason)
+		}
+	}
+	return nil
+}
+
+// write 메시지 전송 (거래는 witness 포함)
+func (p *p2pBroadcaster) write(conn net.Conn, msg wire.Message) error {
+	_, err := wire.WriteMessageWithEncodingN(conn, msg, wire.ProtocolVersion, p.params.Net, wire.WitnessEncoding)
+	if err != nil {
+		return transportError(err)
+	}
+	return nil
+}
+
+// read 메시지 수신 (모르는 메시지는 건너뜀)
+func (p *p2pBroadcaster) read(conn net.Conn) (wire.Message, error) {
+	for {
+		msg, _, err := wire.ReadMessage(conn, wire.ProtocolVersion, p.params.Net)
+		if errors.Is(err, wire.ErrUnknownMessage) {
+			continue
+		}
+		if err != nil {
+			return nil, transportError(err)
+		}
+		return msg, nil
+	}
+}
+
+// p2pBackend 거래 브로드캐스트를 P2P 노드로 보내는 백엔드 (나머지 조회는 내부 백엔드 사용)
+type p2pBackend struct {
+	ChainBackend
+	broadcaster *p2pBroadcaster
+	mode        string
+}
+
+// withP2P P2P 브로드캐스트가 켜져 있으면 백엔드를 감쌈
+func withP2P(ctx context.Context, backend ChainBackend, config P2PConfig, dial dialContextFunc) (ChainBackend, error) {
+	if !config.Enabled {
+		return backend, nil
+	}
+
+	mode := config.Mode
+	if mode == "" {
+		mode = P2PModeAdditional
+	}
+	if mode != P2PModeOnly && mode != P2PModeAdditional {
+		return nil, fmt.Errorf("지원되지 않는 P2P 브로드캐스트 방식: %s", config.Mode)
+	}
+
+	broadcaster, err := newP2PBroadcaster(ctx, config, dial)
+	if err != nil {
+		return nil, err
+	}
+	return &p2pBackend{ChainBackend: backend, broadcaster: broadcaster, mode: mode}, nil
+}
+
+// Broadcast 거래 브로드캐스트 (additional 방식은 백엔드와 P2P 중 한 곳이라도 성공하면 성공)
+func (b *p2pBackend) Broadcast(txHex string) (string, error) {
+	raw, err := hex.DecodeString(txHex)
+	if err != nil {
+		return "", fmt.Errorf("거래 hex 디코딩 실패: %v", err)
+	}
+	tx := wire.NewMsgTx(wire.TxVersion)
+	if err := tx.Deserialize(bytes.NewReader(raw)); err != nil {
+		return "", fmt.Errorf("거래 파싱 실패: %v", err)
+	}
+	txid := tx.TxHash().String()
+
+	if b.mode == P2PModeOnly {
+		if err := b.broadcaster.broadcast(tx); err != nil {
+			return "", err
+		}
+		return txid, nil
+	}
+
+	// 백엔드가 받아들이면 P2P 전달과 확인은 기다리지 않음 (백그라운드에서 계속 진행)
+	p2pDone := make(chan error, 1)
+	go func() {
+		p2pDone <- b.broadcaster.broadcast(tx)
+	}()
+	if _, httpErr := b.ChainBackend.Broadcast(txHex); httpErr != nil {
+		if p2pErr := <-p2pDone; p2pErr != nil {
+			return "", fmt.Errorf("%w (%v)", httpErr, p2pErr)
+		}
+	}
+	return txid, nil
+}
+
+// P2PConfigResponse P2P 브로드캐스트 설정 응답 구조체
+type P2PConfigResponse struct {
+	Success   bool      `json:"success"`   // 성공 여부
+	Message   string    `json:"message"`   // 응답 메시지
+	ErrorCode string    `json:"errorCode"` // 에러 코드 (다국어 처리용)
+	Config    P2PConfig `json:"config"`    // 적용된 설정
+}
+
+// GetP2PConfig 현재 P2P 브로드캐스트 설정 반환
+func (a *App) GetP2PConfig() P2PConfig {
+	a.configMu.RLock()
+	defer a.configMu.RUnlock()
+	return a.config.P2P
+}
+
+// SetP2PConfig P2P 브로드캐스트 설정 변경 및 설정 파일 저장
+func (a *App) SetP2PConfig(config P2PConfig) P2PConfigResponse {
+	a.configMu.RLock()
+	appConfig := a.config
+	a.configMu.RUnlock()
+
+	appConfig.P2P = config
+	if err := a.applyConfig(appConfig); err != nil {
+		return P2PConfigResponse{
+			Success:   false,
+			Message:   err.Error(),
+			ErrorCode: "P2P_CONFIG_INVALID",
+		}
+	}
+
+	if err := saveAppConfig(appConfig); err != nil {
+		return P2PConfigResponse{
+			Success: false,
+			Message: fmt.Sprintf("설정 파일 저장 실패: %v", err),
+			Config:  config,
+		}
+	}
+
+	return P2PConfigResponse{
+		Success: true,
+		Message: "P2P 브로드캐스트 설정이 저장되었습니다",
+		Config:  config,
+	}
+}
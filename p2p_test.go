@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// 모의 피어가 inv를 받았을 때의 동작
+const (
+	peerRequests = "requests" // 바로 getdata로 거래 요청 후 멤풀에 넣음
+	peerDelays   = "delays"   // ping에 먼저 pong한 뒤 getdata (Bitcoin Core의 인바운드 요청 지연)
+	peerKnows    = "knows"    // 이미 멤풀에 있는 거래라 getdata 없이 pong만 보냄
+	peerRejects  = "rejects"  // 거래를 받은 뒤 reject (BIP61을 지원하는 옛 노드)
+	peerDrops    = "drops"    // 거래를 받고 reject 없이 버림 (최신 노드의 거부)
+	peerIgnores  = "ignores"  // inv를 무시하고 pong만 보냄
+)
+
+// mockPeer TCP로 접속을 받는 모의 regtest 노드
+type mockPeer struct {
+	listener net.Listener
+	behavior string
+
+	mu       sync.Mutex
+	received []*wire.MsgTx
+	mempool  map[chainhash.Hash]*wire.MsgTx
+	relays   map[*mockConn]bool // 거래 알림을 받겠다고 한 연결
+}
+
+// mockConn 모의 피어에 접속한 연결 (다른 연결의 거래 알림과 쓰기가 겹치지 않도록 보호)
+type mockConn struct {
+	conn net.Conn
+	mu   sync.Mutex
+}
+
+func (c *mockConn) write(msg wire.Message) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := wire.WriteMessageWithEncodingN(c.conn, msg, wire.ProtocolVersion, chaincfg.RegressionNetParams.Net, wire.WitnessEncoding)
+	return err == nil
+}
+
+func newMockPeer(t *testing.T, behavior string) *mockPeer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	peer := &mockPeer{
+		listener: listener,
+		behavior: behavior,
+		mempool:  make(map[chainhash.Hash]*wire.MsgTx),
+		relays:   make(map[*mockConn]bool),
+	}
+	t.Cleanup(func() { listener.Close() })
+	go peer.accept()
+	return peer
+}
+
+func (m *mockPeer) accept() {
+	for {
+		conn, err := m.listener.Accept()
+		if err != nil {
+			return
+		}
+		go m.serve(&mockConn{conn: conn})
+	}
+}
+
+// addToMempool 거래를 멤풀에 넣고 알림을 받는 다른 연결에 inv 전송
+func (m *mockPeer) addToMempool(tx *wire.MsgTx, from *mockConn) {
+	hash := tx.TxHash()
+	m.mu.Lock()
+	m.mempool[hash] = tx
+	var relays []*mockConn
+	for c := range m.relays {
+		if c != from {
+			relays = append(relays, c)
+		}
+	}
+	m.mu.Unlock()
+
+	inv := wire.NewMsgInv()
+	inv.AddInvVect(wire.NewInvVect(wire.InvTypeTx, &hash))
+	for _, c := range relays {
+		c.write(inv)
+	}
+}
+
+func (m *mockPeer) serve(c *mockConn) {
+	defer func() {
+		m.mu.Lock()
+		delete(m.relays, c)
+		m.mu.Unlock()
+		c.conn.Close()
+	}()
+	magic := chaincfg.RegressionNetParams.Net
+
+	var pending *chainhash.Hash
+	for {
+		msg, _, err := wire.ReadMessage(c.conn, wire.ProtocolVersion, magic)
+		if err != nil {
+			return
+		}
+
+		switch msg := msg.(type) {
+		case *wire.MsgVersion:
+			if !msg.DisableRelayTx {
+				m.mu.Lock()
+				m.relays[c] = true
+				m.mu.Unlock()
+			}
+			me := wire.NewNetAddressIPPort(net.IPv4zero, 0, 0)
+			version := wire.NewMsgVersion(me, me, 1, 0)
+			if !c.write(version) || !c.write(wire.NewMsgVerAck()) {
+				return
+			}
+		case *wire.MsgInv:
+			hash := msg.InvList[0].Hash
+			switch m.behavior {
+			case peerRequests, peerRejects, peerDrops:
+				getData := wire.NewMsgGetData()
+				getData.AddInvVect(wire.NewInvVect(wire.InvTypeWitnessTx, &hash))
+				if !c.write(getData) {
+					return
+				}
+			case peerDelays:
+				pending = &hash
+			}
+		case *wire.MsgGetData:
+			// 멤풀에 있는 거래만 돌려줌 (Bitcoin Core는 2분 넘게 멤풀에 있던 거래를 돌려줌)
+			for _, vect := range msg.InvList {
+				m.mu.Lock()
+				tx, ok := m.mempool[vect.Hash]
+				m.mu.Unlock()
+				if ok {
+					c.write(tx)
+				} else {
+					notFound := wire.NewMsgNotFound()
+					notFound.AddInvVect(vect)
+					c.write(notFound)
+				}
+			}
+		case *wire.MsgPing:
+			if !c.write(wire.NewMsgPong(msg.Nonce)) {
+				return
+			}
+			if pending != nil {
+				time.Sleep(20 * time.Millisecond)
+				getData := wire.NewMsgGetData()
+				getData.AddInvVect(wire.NewInvVect(wire.InvTypeWitnessTx, pending))
+				pending = nil
+				if !c.write(getData) {
+					return
+				}
+			}
+		case *wire.MsgTx:
+			m.mu.Lock()
+			m.received = append(m.received, msg)
+			m.mu.Unlock()
+			switch m.behavior {
+			case peerRejects:
+				reject := wire.NewMsgReject("tx", wire.RejectInsufficientFee, "min relay fee not met")
+				reject.Hash = msg.TxHash()
+				if !c.write(reject) {
+					return
+				}
+			case peerRequests, peerDelays:
+				m.addToMempool(msg, c)
+			}
+		}
+	}
+}
+
+func (m *mockPeer) receivedTxs() []*wire.MsgTx {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*wire.MsgTx(nil), m.received...)
+}
+
+func newTestBroadcaster(t *testing.T, peers ...*mockPeer) *p2pBroadcaster {
+	t.Helper()
+	var addrs []string
+	for _, peer := range peers {
+		addrs = append(addrs, peer.listener.Addr().String())
+	}
+	broadcaster, err := newP2PBroadcaster(context.Background(), P2PConfig{Peers: addrs, Network: "regtest"}, directDial)
+	if err != nil {
+		t.Fatalf("newP2PBroadcaster: %v", err)
+	}
+	broadcaster.getDataWait = 200 * time.Millisecond
+	broadcaster.announceWait = 200 * time.Millisecond
+	broadcaster.probeWait = 200 * time.Millisecond
+	return broadcaster
+}
+
+func TestP2PBroadcast(t *testing.T) {
+	tests := []struct {
+		behavior      string
+		wantDelivered bool
+		wantErrSubstr string
+		wantErrCode   string
+	}{
+		{behavior: peerRequests, wantDelivered: true},
+		{behavior: peerDelays, wantDelivered: true},
+		{behavior: peerKnows},
+		{behavior: peerRejects, wantDelivered: true, wantErrSubstr: "min relay fee not met"},
+		{behavior: peerDrops, wantDelivered: true, wantErrCode: ErrorCodeP2PUnconfirmed},
+		{behavior: peerIgnores, wantErrCode: ErrorCodeP2PUnconfirmed},
+	}
+
+	for _, tt := range tests {
+		peer := newMockPeer(t, tt.behavior)
+		tx := testSpendTx(7)
+		if tt.behavior == peerKnows {
+			peer.addToMempool(tx, nil)
+		}
+
+		err := newTestBroadcaster(t, peer).broadcast(tx)
+		switch {
+		case tt.wantErrSubstr != "":
+			if err == nil || !strings.Contains(err.Error(), tt.wantErrSubstr) {
+				t.Errorf("%s: broadcast = %v, want error containing %q", tt.behavior, err, tt.wantErrSubstr)
+			}
+		case tt.wantErrCode != "":
+			if err == nil || backendErrorCode(err) != tt.wantErrCode {
+				t.Errorf("%s: broadcast = %v, want %s", tt.behavior, err, tt.wantErrCode)
+			}
+		case err != nil:
+			t.Errorf("%s: broadcast: %v", tt.behavior, err)
+		}
+
+		received := peer.receivedTxs()
+		if tt.wantDelivered && (len(received) != 1 || received[0].TxHash() != tx.TxHash()) {
+			t.Errorf("%s: peer received %d transactions, want the broadcast tx", tt.behavior, len(received))
+		}
+		if !tt.wantDelivered && len(received) != 0 {
+			t.Errorf("%s: peer received %d transactions, want none", tt.behavior, len(received))
+		}
+	}
+}
+
+func TestP2PBroadcastAnyPeerSucceeds(t *testing.T) {
+	// 연결을 바로 끊는 피어
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	good := newMockPeer(t, peerRequests)
+	broadcaster := newTestBroadcaster(t, good)
+	broadcaster.peers = append([]string{listener.Addr().String()}, broadcaster.peers...)
+
+	if err := broadcaster.broadcast(testSpendTx(8)); err != nil {
+		t.Errorf("broadcast with one healthy peer: %v", err)
+	}
+
+	broadcaster.peers = broadcaster.peers[:1]
+	err = broadcaster.broadcast(testSpendTx(9))
+	if err == nil || backendErrorCode(err) != ErrorCodeBackendUnavailable {
+		t.Errorf("broadcast with only a failing peer = %v, want %s", err, ErrorCodeBackendUnavailable)
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// paymentURIScheme BIP21 URI 스킴
+const paymentURIScheme = "bitcoin:"
+
+// PaymentURIRequest BIP21 결제 URI 생성 요청 구조체
+type PaymentURIRequest struct {
+	Address   string `json:"address"`   // 받는 주소
+	AmountSat int64  `json:"amountSat"` // 요청 금액 (satoshi, 0이면 생략)
+	Label     string `json:"label"`     // 받는 사람 이름 (선택)
+	Message   string `json:"message"`   // 결제 메모 (선택)
+}
+
+// PaymentURIResponse BIP21 결제 URI 파싱/생성 응답 구조체
+type PaymentURIResponse struct {
+	Success        bool   `json:"success"`        // 성공 여부
+	Message        string `json:"message"`        // 응답 메시지
+	ErrorCode      string `json:"errorCode"`      // 에러 코드 (다국어 처리용)
+	URI            string `json:"uri"`            // 결제 URI
+	Address        string `json:"address"`        // 받는 주소
+	AmountSat      int64  `json:"amountSat"`      // 요청 금액 (satoshi)
+	Label          string `json:"label"`          // 받는 사람 이름
+	PaymentMessage string `json:"paymentMessage"` // 결제 메모
+}
+
+// escapeURIComponent BIP21 파라미터 값 인코딩 (RFC 3986, 공백은 %20)
+func escapeURIComponent(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+// parsePaymentURI BIP21 결제 URI 파싱 (알 수 없는 req- 파라미터는 규격에 따라 거부)
+// 실패 시 다국어 처리를 위한 에러 코드를 함께 반환
+func parsePaymentURI(uri string) (PaymentURIResponse, string, error) {
+	uri = strings.TrimSpace(uri)
+	if len(uri) < len(paymentURIScheme) || !strings.EqualFold(uri[:len(paymentURIScheme)], paymentURIScheme) {
+		return PaymentURIResponse{}, "URI_INVALID_SCHEME", fmt.Errorf("bitcoin: 으로 시작하는 URI가 아닙니다")
+	}
+	rest := uri[len(paymentURIScheme):]
+
+	addressPart, query := rest, ""
+	if idx := strings.IndexByte(rest, '?'); idx >= 0 {
+		addressPart, query = rest[:idx], rest[idx+1:]
+	}
+
+	decoded, err := btcutil.DecodeAddress(addressPart, &chaincfg.MainNetParams)
+	if err != nil {
+		return PaymentURIResponse{}, "URI_INVALID_ADDRESS", fmt.Errorf("주소 형식 오류: %v", err)
+	}
+
+	result := PaymentURIResponse{
+		Address: decoded.EncodeAddress(),
+	}
+
+	seen := make(map[string]bool)
+	for _, param := range strings.Split(query, "&") {
+		if param == "" {
+			continue
+		}
+
+		key, rawValue, _ := strings.Cut(param, "=")
+		key = strings.ToLower(key)
+		// BIP21은 '+'를 공백으로 취급하지 않으므로 PathUnescape 사용
+		value, err := url.PathUnescape(rawValue)
+		if err != nil {
+			return PaymentURIResponse{}, "URI_INVALID_PARAMETER", fmt.Errorf("잘못된 파라미터 인코딩: %s", key)
+		}
+
+		if seen[key] {
+			return PaymentURIResponse{}, "URI_INVALID_PARAMETER", fmt.Errorf("중복된 파라미터: %s", key)
+		}
+		seen[key] = true
+
+		switch key {
+		case "amount":
+			// BIP21 금액은 단위 없는 10진수 BTC 값만 허용
+			if value == "" || strings.Trim(value, "0123456789.") != "" {
+				return PaymentURIResponse{}, "URI_INVALID_AMOUNT", fmt.Errorf("잘못된 금액: %q", value)
+			}
+			amountSat, err := parseAmount(value, UnitBTC)
+			if err != nil {
+				return PaymentURIResponse{}, "URI_INVALID_AMOUNT", err
+			}
+			result.AmountSat = amountSat
+		case "label":
+			result.Label = value
+		case "message":
+			result.PaymentMessage = value
+		default:
+			// 이해하지 못한 필수(req-) 파라미터가 있으면 URI 전체를 거부
+			if strings.HasPrefix(key, "req-") {
+				return PaymentURIResponse{}, "URI_UNSUPPORTED_REQUIREMENT", fmt.Errorf("지원하지 않는 필수 파라미터: %s", key)
+			}
+		}
+	}
+
+	return result, "", nil
+}
+
+// buildPaymentURI BIP21 결제 URI 생성
+func buildPaymentURI(request PaymentURIRequest) (string, error) {
+	decoded, err := btcutil.DecodeAddress(strings.TrimSpace(request.Address), &chaincfg.MainNetParams)
+	if err != nil {
+		return "", fmt.Errorf("주소 형식 오류: %v", err)
+	}
+
+	if request.AmountSat < 0 {
+		return "", fmt.Errorf("요청 금액은 0 이상이어야 합니다")
+	}
+
+	var params []string
+	if request.AmountSat > 0 {
+		amount, err := formatAmount(request.AmountSat, UnitBTC)
+		if err != nil {
+			return "", err
+		}
+		// 불필요한 소수점 이하 0 제거 (0.01000000 → 0.01)
+		amount = strings.TrimRight(strings.TrimRight(amount, "0"), ".")
+		params = append(params, "amount="+amount)
+	}
+	if request.Label != "" {
+		params = append(params, "label="+escapeURIComponent(request.Label))
+	}
+	if request.Message != "" {
+		params = append(params, "message="+escapeURIComponent(request.Message))
+	}
+
+	uri := paymentURIScheme + decoded.EncodeAddress()
+	if len(params) > 0 {
+		uri += "?" + strings.Join(params, "&")
+	}
+
+	return uri, nil
+}
+
+// ParsePaymentURI 사용자가 붙여넣거나 QR로 스캔한 BIP21 결제 URI 파싱
+func (a *App) ParsePaymentURI(uri string) PaymentURIResponse {
+	result, errorCode, err := parsePaymentURI(uri)
+	if err != nil {
+		return PaymentURIResponse{
+			Success:   false,
+			Message:   err.Error(),
+			ErrorCode: errorCode,
+		}
+	}
+
+	result.Success = true
+	result.Message = "성공"
+	result.URI = strings.TrimSpace(uri)
+	return result
+}
+
+// BuildPaymentURI 받기 요청용 BIP21 결제 URI 생성 (주소 QR 코드에 사용)
+func (a *App) BuildPaymentURI(request PaymentURIRequest) PaymentURIResponse {
+	uri, err := buildPaymentURI(request)
+	if err != nil {
+		return PaymentURIResponse{
+			Success:   false,
+			Message:   err.Error(),
+			ErrorCode: "URI_BUILD_FAILED",
+		}
+	}
+
+	return PaymentURIResponse{
+		Success:        true,
+		Message:        "성공",
+		URI:            uri,
+		Address:        request.Address,
+		AmountSat:      request.AmountSat,
+		Label:          request.Label,
+		PaymentMessage: request.Message,
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// defaultProxyAddress Tor SOCKS5 기본 주소
+const defaultProxyAddress = "127.0.0.1:9050"
+
+// dialTimeout 서버(또는 프록시) 연결 시간 제한
+const dialTimeout = 30 * time.Second
+
+// errProxyUnavailable 프록시 필수 설정에서 프록시에 연결할 수 없을 때의 오류
+var errProxyUnavailable = errors.New("프록시에 연결할 수 없어 요청을 거부했습니다")
+
+// ProxyConfig SOCKS5 프록시 설정 (Tor 등)
+type ProxyConfig struct {
+	Enabled  bool   `json:"enabled"`  // 프록시 사용 여부
+	Address  string `json:"address"`  // 프록시 주소 (호스트:포트, 기본값 Tor 127.0.0.1:9050)
+	Username string `json:"username"` // 프록시 사용자 이름 (Tor 회로 분리용, 선택)
+	Password string `json:"password"` // 프록시 비밀번호 (선택)
+	Required bool   `json:"required"` // 프록시를 사용할 수 없으면 직접 연결하지 않고 요청 거부
+}
+
+// dialContextFunc 모든 백엔드가 공유하는 연결 함수
+type dialContextFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// directDial 프록시 없이 직접 연결
+func directDial(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	return dialer.DialContext(ctx, network, address)
+}
+
+// proxyForwardDialer 프록시 서버 자체와의 연결 실패 여부를 기록하는 dialer
+type proxyForwardDialer struct {
+	failed bool
+}
+
+// Dial 프록시 서버에 연결
+func (d *proxyForwardDialer) Dial(network, address string) (net.Conn, error) {
+	return d.DialContext(context.Background(), network, address)
+}
+
+// DialContext 프록시 서버에 연결 (실패 시 기록)
+func (d *proxyForwardDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	conn, err := directDial(ctx, network, address)
+	if err != nil {
+		d.failed = true
+	}
+	return conn, err
+}
+
+// newDialer 프록시 설정에 맞는 연결 함수 생성
+// SOCKS5 프록시는 호스트 이름을 그대로 전달하므로 DNS 조회도 프록시를 거침 (.onion 주소 사용 가능)
+func newDialer(config ProxyConfig) (dialContextFunc, error) {
+	if !config.Enabled {
+		return directDial, nil
+	}
+
+	address := strings.TrimSpace(config.Address)
+	if address == "" {
+		address = defaultProxyAddress
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return nil, fmt.Errorf("잘못된 프록시 주소: %s", config.Address)
+	}
+
+	var auth *proxy.Auth
+	if config.Username != "" {
+		auth = &proxy.Auth{User: config.Username, Password: config.Password}
+	}
+
+	return func(ctx context.Context, network, target string) (net.Conn, error) {
+		forward := &proxyForwardDialer{}
+		socks, err := proxy.SOCKS5("tcp", address, auth, forward)
+		if err != nil {
+			return nil, err
+		}
+
+		conn, err := socks.(proxy.ContextDialer).DialContext(ctx, network, target)
+		if err == nil {
+			return conn, nil
+		}
+
+		// 프록시 서버 자체에 연결하지 못한 경우에만 직접 연결로 대체 (대상 서버 오류는 그대로 반환)
+		if forward.failed {
+			if config.Required {
+				return nil, fmt.Errorf("%w (%s)", errProxyUnavailable, address)
+			}
+			return directDial(ctx, network, target)
+		}
+		return nil, err
+	}, nil
+}
+
+// newHTTPClient 연결 함수를 사용하는 HTTP 클라이언트 생성 (환경 변수 프록시는 사용하지 않음)
+func newHTTPClient(dial dialContextFunc) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy:               nil,
+			DialContext:         dial,
+			TLSHandshakeTimeout: dialTimeout,
+			IdleConnTimeout:     90 * time.Second,
+		},
+	}
+}
+
+// ProxyConfigResponse 프록시 설정 응답 구조체
+type ProxyConfigResponse struct {
+	Success   bool        `json:"success"`   // 성공 여부
+	Message   string      `json:"message"`   // 응답 메시지
+	ErrorCode string      `json:"errorCode"` // 에러 코드 (다국어 처리용)
+	Config    ProxyConfig `json:"config"`    // 적용된 설정
+}
+
+// GetProxyConfig 현재 프록시 설정 반환
+func (a *App) GetProxyConfig() ProxyConfig {
+	a.configMu.RLock()
+	defer a.configMu.RUnlock()
+	return a.config.Proxy
+}
+
+// SetProxyConfig 프록시 설정 변경 및 설정 파일 저장 (모든 백엔드 연결에 즉시 적용)
+func (a *App) SetProxyConfig(config ProxyConfig) ProxyConfigResponse {
+	a.configMu.RLock()
+	appConfig := a.config
+	a.configMu.RUnlock()
+
+	appConfig.Proxy = config
+	if err := a.applyConfig(appConfig); err != nil {
+		return ProxyConfigResponse{
+			Success:   false,
+			Message:   err.Error(),
+			ErrorCode: "PROXY_CONFIG_INVALID",
+		}
+	}
+
+	if err := saveAppConfig(appConfig); err != nil {
+		return ProxyConfigResponse{
+			Success: false,
+			Message: fmt.Sprintf("설정 파일 저장 실패: %v", err),
+			Config:  config,
+		}
+	}
+
+	return ProxyConfigResponse{
+		Success: true,
+		Message: "프록시 설정이 저장되었습니다",
+		Config:  config,
+	}
+}
@@ -0,0 +1,353 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// 교차 검증 불일치 종류
+const (
+	DiscrepancyUTXOMissing = "utxo_missing" // 한쪽 백엔드에만 있는 확인된 UTXO
+	DiscrepancyUTXOValue   = "utxo_value"   // UTXO 금액 불일치
+	DiscrepancyTxOutput    = "tx_output"    // 거래 출력(금액/스크립트) 불일치
+	DiscrepancyTxInput     = "tx_input"     // 거래 입력 또는 이전 출력 불일치
+	DiscrepancyTxFee       = "tx_fee"       // 거래 수수료 불일치
+	DiscrepancyTxRaw       = "tx_raw"       // 원본 거래 데이터 불일치
+)
+
+// ErrorCodeBackendDiscrepancy 백엔드 간 결과 불일치 에러 코드
+const ErrorCodeBackendDiscrepancy = "BACKEND_DISCREPANCY"
+
+// QuorumConfig 여러 백엔드 교차 검증 설정
+type QuorumConfig struct {
+	Enabled  bool            `json:"enabled"`  // 교차 검증 사용 여부
+	Backends []BackendConfig `json:"backends"` // 기본 백엔드와 비교할 추가 백엔드 목록
+}
+
+// QuorumDiscrepancy 백엔드 간 불일치 항목
+type QuorumDiscrepancy struct {
+	Backend  string `json:"backend"`  // 기본 백엔드와 다른 결과를 반환한 백엔드
+	Kind     string `json:"kind"`     // 불일치 종류
+	Item     string `json:"item"`     // 대상 (txid:vout 등)
+	Expected string `json:"expected"` // 기본 백엔드 결과
+	Actual   string `json:"actual"`   // 비교 백엔드 결과
+}
+
+// quorumError 교차 검증 실패 오류
+type quorumError struct {
+	discrepancies []QuorumDiscrepancy
+}
+
+// Error 오류 메시지 (불일치 항목 요약)
+func (e *quorumError) Error() string {
+	items := make([]string, 0, len(e.discrepancies))
+	for i, d := range e.discrepancies {
+		if i == 5 {
+			items = append(items, fmt.Sprintf("외 %d건", len(e.discrepancies)-5))
+			break
+		}
+		items = append(items, fmt.Sprintf("%s %s %s (기본: %s, 비교: %s)", d.Backend, d.Kind, d.Item, d.Expected, d.Actual))
+	}
+	return "백엔드 간 결과가 일치하지 않습니다: " + strings.Join(items, "; ")
+}
+
+// quorumDiscrepancies 오류에 포함된 교차 검증 불일치 항목 (없으면 nil)
+func quorumDiscrepancies(err error) []QuorumDiscrepancy {
+	var qErr *quorumError
+	if errors.As(err, &qErr) {
+		return qErr.discrepancies
+	}
+	return nil
+}
+
+// quorumBackend 여러 백엔드에서 UTXO와 거래를 조회하여 비교하는 백엔드
+// 첫 번째 백엔드가 기본이며, 하나라도 결과가 다르면 불일치 보고서와 함께 실패함
+// 주소 통계, 수수료율, 블록 높이는 기본 백엔드 결과를 사용
+type quorumBackend struct {
+	ChainBackend
+	backends []ChainBackend
+}
+
+// newQuorumBackend 교차 검증 백엔드 생성 (backends[0]이 기본 백엔드)
+func newQuorumBackend(backends []ChainBackend) *quorumBackend {
+	return &quorumBackend{
+		ChainBackend: backends[0],
+		backends:     backends,
+	}
+}
+
+// Name 백엔드 이름
+func (q *quorumBackend) Name() string {
+	names := make([]string, len(q.backends))
+	for i, backend := range q.backends {
+		names[i] = backend.Name()
+	}
+	return "quorum(" + strings.Join(names, ", ") + ")"
+}
+
+// fetchAll 모든 백엔드에 동시에 요청 (하나라도 실패하면 교차 검증 불가로 오류)
+func fetchAll[T any](backends []ChainBackend, fetch func(ChainBackend) (T, error)) ([]T, error) {
+	results := make([]T, len(backends))
+	errs := make([]error, len(backends))
+
+	var wg sync.WaitGroup
+	for i, backend := range backends {
+		wg.Add(1)
+		go func(i int, backend ChainBackend) {
+			defer wg.Done()
+			results[i], errs[i] = fetch(backend)
+		}(i, backend)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", backends[i].Name(), err)
+		}
+	}
+	return results, nil
+}
+
+// AddressUTXOs 모든 백엔드의 UTXO를 비교하여 기본 백엔드 결과 반환
+// 확인된 UTXO는 모든 백엔드에 같은 금액으로 있어야 하며 (블록 전파 지연으로 미확인 상태인 것은 허용),
+// 모든 백엔드에서 확인된 UTXO만 확인됨으로 표시함
+func (q *quorumBackend) AddressUTXOs(address string) ([]UTXO, error) {
+	results, err := fetchAll(q.backends, func(b ChainBackend) ([]UTXO, error) {
+		return b.AddressUTXOs(address)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	index := func(utxos []UTXO) map[string]UTXO {
+		m := make(map[string]UTXO, len(utxos))
+		for _, utxo := range utxos {
+			m[fmt.Sprintf("%s:%d", utxo.TxID, utxo.Vout)] = utxo
+		}
+		return m
+	}
+
+	primary := index(results[0])
+	confirmedEverywhere := make(map[string]bool, len(primary))
+	for key, utxo := range primary {
+		confirmedEverywhere[key] = utxo.Status.Confirmed
+	}
+
+	var discrepancies []QuorumDiscrepancy
+	for i := 1; i < len(results); i++ {
+		name := q.backends[i].Name()
+		other := index(results[i])
+
+		for key, utxo := range primary {
+			otherUTXO, ok := other[key]
+			if !ok {
+				if utxo.Status.Confirmed {
+					discrepancies = append(discrepancies, QuorumDiscrepancy{
+						Backend: name, Kind: DiscrepancyUTXOMissing, Item: key,
+						Expected: fmt.Sprintf("%d sat", utxo.Value), Actual: "없음",
+					})
+				}
+				confirmedEverywhere[key] = false
+				continue
+			}
+			if otherUTXO.Value != utxo.Value {
+				discrepancies = append(discrepancies, QuorumDiscrepancy{
+					Backend: name, Kind: DiscrepancyUTXOValue, Item: key,
+					Expected: fmt.Sprintf("%d sat", utxo.Value), Actual: fmt.Sprintf("%d sat", otherUTXO.Value),
+				})
+			}
+			if !otherUTXO.Status.Confirmed {
+				confirmedEverywhere[key] = false
+			}
+		}
+
+		for key, utxo := range other {
+			if _, ok := primary[key]; !ok && utxo.Status.Confirmed {
+				discrepancies = append(discrepancies, QuorumDiscrepancy{
+					Backend: name, Kind: DiscrepancyUTXOMissing, Item: key,
+					Expected: "없음", Actual: fmt.Sprintf("%d sat", utxo.Value),
+				})
+			}
+		}
+	}
+
+	if len(discrepancies) > 0 {
+		return nil, &quorumError{discrepancies: discrepancies}
+	}
+
+	utxos := make([]UTXO, len(results[0]))
+	for i, utxo := range results[0] {
+		utxo.Status.Confirmed = confirmedEverywhere[fmt.Sprintf("%s:%d", utxo.TxID, utxo.Vout)]
+		utxos[i] = utxo
+	}
+	return utxos, nil
+}
+
+// Transaction 모든 백엔드의 거래 세부정보(출력, 입력의 이전 출력, 수수료)를 비교하여 기본 백엔드 결과 반환
+// 확인 상태는 전파 시점에 따라 다를 수 있으므로 비교하지 않음
+func (q *quorumBackend) Transaction(txid string) (*TxDetails, error) {
+	results, err := fetchAll(q.backends, func(b ChainBackend) (*TxDetails, error) {
+		return b.Transaction(txid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	primary := results[0]
+	var discrepancies []QuorumDiscrepancy
+	for i := 1; i < len(results); i++ {
+		discrepancies = append(discrepancies, compareTxDetails(q.backends[i].Name(), primary, results[i])...)
+	}
+
+	if len(discrepancies) > 0 {
+		return nil, &quorumError{discrepancies: discrepancies}
+	}
+	return primary, nil
+}
+
+// compareTxDetails 두 백엔드의 거래 세부정보 비교
+func compareTxDetails(name string, expected, actual *TxDetails) []QuorumDiscrepancy {
+	var discrepancies []QuorumDiscrepancy
+	add := func(kind, item, exp, act string) {
+		discrepancies = append(discrepancies, QuorumDiscrepancy{
+			Backend: name, Kind: kind, Item: item, Expected: exp, Actual: act,
+		})
+	}
+
+	if len(expected.Vout) != len(actual.Vout) {
+		add(DiscrepancyTxOutput, expected.TxID, fmt.Sprintf("출력 %d개", len(expected.Vout)), fmt.Sprintf("출력 %d개", len(actual.Vout)))
+	} else {
+		for i := range expected.Vout {
+			item := fmt.Sprintf("%s:%d", expected.TxID, i)
+			if expected.Vout[i].Value != actual.Vout[i].Value {
+				add(DiscrepancyTxOutput, item, fmt.Sprintf("%d sat", expected.Vout[i].Value), fmt.Sprintf("%d sat", actual.Vout[i].Value))
+			}
+			if expected.Vout[i].ScriptPubKey != actual.Vout[i].ScriptPubKey {
+				add(DiscrepancyTxOutput, item, expected.Vout[i].ScriptPubKey, actual.Vout[i].ScriptPubKey)
+			}
+		}
+	}
+
+	if len(expected.Vin) != len(actual.Vin) {
+		add(DiscrepancyTxInput, expected.TxID, fmt.Sprintf("입력 %d개", len(expected.Vin)), fmt.Sprintf("입력 %d개", len(actual.Vin)))
+	} else {
+		for i := range expected.Vin {
+			exp, act := expected.Vin[i], actual.Vin[i]
+			item := fmt.Sprintf("%s 입력 %d", expected.TxID, i)
+			if exp.TxID != act.TxID || exp.Vout != act.Vout {
+				add(DiscrepancyTxInput, item, fmt.Sprintf("%s:%d", exp.TxID, exp.Vout), fmt.Sprintf("%s:%d", act.TxID, act.Vout))
+				continue
+			}
+			if exp.Prevout != nil && act.Prevout != nil {
+				if exp.Prevout.Value != act.Prevout.Value {
+					add(DiscrepancyTxInput, item, fmt.Sprintf("%d sat", exp.Prevout.Value), fmt.Sprintf("%d sat", act.Prevout.Value))
+				}
+				if exp.Prevout.ScriptPubKey != act.Prevout.ScriptPubKey {
+					add(DiscrepancyTxInput, item, exp.Prevout.ScriptPubKey, act.Prevout.ScriptPubKey)
+				}
+			}
+		}
+	}
+
+	if expected.Fee != actual.Fee {
+		add(DiscrepancyTxFee, expected.TxID, fmt.Sprintf("%d sat", expected.Fee), fmt.Sprintf("%d sat", actual.Fee))
+	}
+
+	return discrepancies
+}
+
+// RawTransaction 모든 백엔드의 원본 거래를 비교하여 기본 백엔드 결과 반환
+func (q *quorumBackend) RawTransaction(txid string) ([]byte, error) {
+	results, err := fetchAll(q.backends, func(b ChainBackend) ([]byte, error) {
+		return b.RawTransaction(txid)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var discrepancies []QuorumDiscrepancy
+	for i := 1; i < len(results); i++ {
+		if !bytes.Equal(results[0], results[i]) {
+			discrepancies = append(discrepancies, QuorumDiscrepancy{
+				Backend: q.backends[i].Name(), Kind: DiscrepancyTxRaw, Item: txid,
+				Expected: fmt.Sprintf("%d bytes", len(results[0])), Actual: fmt.Sprintf("%d bytes", len(results[i])),
+			})
+		}
+	}
+
+	if len(discrepancies) > 0 {
+		return nil, &quorumError{discrepancies: discrepancies}
+	}
+	return results[0], nil
+}
+
+// Broadcast 모든 백엔드로 거래 전송 (하나라도 성공하면 성공, 기본 백엔드 결과 우선)
+func (q *quorumBackend) Broadcast(txHex string) (string, error) {
+	txids := make([]string, len(q.backends))
+	errs := make([]error, len(q.backends))
+
+	var wg sync.WaitGroup
+	for i, backend := range q.backends {
+		wg.Add(1)
+		go func(i int, backend ChainBackend) {
+			defer wg.Done()
+			txids[i], errs[i] = backend.Broadcast(txHex)
+		}(i, backend)
+	}
+	wg.Wait()
+
+	for i := range q.backends {
+		if errs[i] == nil {
+			return txids[i], nil
+		}
+	}
+	return "", errs[0]
+}
+
+// QuorumConfigResponse 교차 검증 설정 응답 구조체
+type QuorumConfigResponse struct {
+	Success   bool         `json:"success"`   // 성공 여부
+	Message   string       `json:"message"`   // 응답 메시지
+	ErrorCode string       `json:"errorCode"` // 에러 코드 (다국어 처리용)
+	Config    QuorumConfig `json:"config"`    // 적용된 설정
+}
+
+// GetQuorumConfig 현재 교차 검증 설정 반환
+func (a *App) GetQuorumConfig() QuorumConfig {
+	a.configMu.RLock()
+	defer a.configMu.RUnlock()
+	return a.config.Quorum
+}
+
+// SetQuorumConfig 교차 검증 설정 변경 및 설정 파일 저장
+func (a *App) SetQuorumConfig(config QuorumConfig) QuorumConfigResponse {
+	a.configMu.RLock()
+	appConfig := a.config
+	a.configMu.RUnlock()
+
+	appConfig.Quorum = config
+	if err := a.applyConfig(appConfig); err != nil {
+		return QuorumConfigResponse{
+			Success:   false,
+			Message:   err.Error(),
+			ErrorCode: "QUORUM_CONFIG_INVALID",
+		}
+	}
+
+	if err := saveAppConfig(appConfig); err != nil {
+		return QuorumConfigResponse{
+			Success: false,
+			Message: fmt.Sprintf("설정 파일 저장 실패: %v", err),
+			Config:  config,
+		}
+	}
+
+	return QuorumConfigResponse{
+		Success: true,
+		Message: "교차 검증 설정이 저장되었습니다",
+		Config:  config,
+	}
+}
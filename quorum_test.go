@@ -0,0 +1,77 @@
+package main
+
+import "testing"
+
+// namedFakeBackend 불일치 보고서에서 구분할 수 있도록 이름을 붙인 가짜 백엔드
+type namedFakeBackend struct {
+	*fakeBackend
+	name string
+}
+
+func (b *namedFakeBackend) Name() string { return b.name }
+
+func TestQuorumUTXODiscrepancy(t *testing.T) {
+	primary := &namedFakeBackend{newFakeBackend(), "primary"}
+	secondary := &namedFakeBackend{newFakeBackend(), "secondary"}
+	primary.utxos[testWalletAddress] = []UTXO{testUTXO(1, 50_000, true), testUTXO(2, 30_000, true)}
+	secondary.utxos[testWalletAddress] = []UTXO{testUTXO(1, 50_000, true), testUTXO(2, 30_000, false)}
+	app := newTestApp(newQuorumBackend([]ChainBackend{primary, secondary}))
+
+	// 한쪽에서 아직 미확인인 UTXO는 불일치가 아니라 미확인으로 취급
+	utxos, err := app.chain().AddressUTXOs(testWalletAddress)
+	if err != nil {
+		t.Fatalf("AddressUTXOs: %v", err)
+	}
+	if !utxos[0].Status.Confirmed || utxos[1].Status.Confirmed {
+		t.Errorf("confirmed flags = %v/%v, want true/false", utxos[0].Status.Confirmed, utxos[1].Status.Confirmed)
+	}
+
+	// 확인된 UTXO의 금액이 다르면 서명 전에 실패
+	secondary.utxos[testWalletAddress] = []UTXO{testUTXO(1, 500_000, true), testUTXO(2, 30_000, true)}
+	response := app.PrepareTransaction(SendBitcoinRequest{
+		WalletData:       WalletData{Address: testWalletAddress},
+		RecipientAddress: testRecipientAddress,
+		AmountSat:        40_000,
+		FeeSatoshi:       2_000,
+	})
+	if response.Success || response.ErrorCode != ErrorCodeBackendDiscrepancy {
+		t.Fatalf("PrepareTransaction = %+v, want %s", response, ErrorCodeBackendDiscrepancy)
+	}
+	want := QuorumDiscrepancy{
+		Backend:  "secondary",
+		Kind:     DiscrepancyUTXOValue,
+		Item:     testTxID(1) + ":0",
+		Expected: "50000 sat",
+		Actual:   "500000 sat",
+	}
+	if len(response.Discrepancies) != 1 || response.Discrepancies[0] != want {
+		t.Errorf("discrepancies = %+v, want [%+v]", response.Discrepancies, want)
+	}
+}
+
+func TestCompareTxDetails(t *testing.T) {
+	details := func(prevout, fee int64) *TxDetails {
+		return &TxDetails{
+			TxID: testTxID(1),
+			Vin:  []TxInput{{TxID: testTxID(2), Prevout: &TxOutput{ScriptPubKey: "0014aa", Value: prevout}}},
+			Vout: []TxOutput{{ScriptPubKey: "0014bb", Value: 90_000}},
+			Fee:  fee,
+		}
+	}
+
+	if got := compareTxDetails("secondary", details(100_000, 10_000), details(100_000, 10_000)); len(got) != 0 {
+		t.Errorf("identical details reported %+v", got)
+	}
+
+	got := compareTxDetails("secondary", details(100_000, 10_000), details(200_000, 110_000))
+	kinds := make(map[string]bool)
+	for _, d := range got {
+		kinds[d.Kind] = true
+		if d.Backend != "secondary" {
+			t.Errorf("discrepancy backend = %q, want secondary", d.Backend)
+		}
+	}
+	if len(got) != 2 || !kinds[DiscrepancyTxInput] || !kinds[DiscrepancyTxFee] {
+		t.Errorf("discrepancies = %+v, want a prevout value and a fee mismatch", got)
+	}
+}
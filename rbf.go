@@ -0,0 +1,450 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	// rbfSequence BIP125 교체 가능 신호를 보내는 입력 시퀀스 값 (0xfffffffd)
+	rbfSequence = wire.MaxTxInSequenceNum - 2
+
+	// incrementalRelayFeeRate BIP125 규칙 4의 최소 추가 수수료율 (sat/vB, Bitcoin Core 기본값)
+	incrementalRelayFeeRate = 1.0
+
+	// maxBumpFeeRate 수수료 올리기 시 허용하는 최대 수수료율 (sat/vB, 입력 실수 방지)
+	maxBumpFeeRate = 1000.0
+)
+
+// BumpFeeRequest 수수료 올리기(RBF) 요청 구조체
+type BumpFeeRequest struct {
+	WalletData WalletData `json:"walletData"` // 지갑 데이터
+	TxID       string     `json:"txid"`       // 교체할 미확인 거래 ID
+	FeeRate    float64    `json:"feeRate"`    // 새 수수료율 (sat/vB)
+}
+
+// BumpFeeResponse 수수료 올리기 응답 구조체
+type BumpFeeResponse struct {
+	Success     bool    `json:"success"`     // 성공 여부
+	Message     string  `json:"message"`     // 응답 메시지
+	ErrorCode   string  `json:"errorCode"`   // 에러 코드 (다국어 처리용)
+	TxHash      string  `json:"txHash"`      // 교체 거래 해시
+	OldFee      int64   `json:"oldFee"`      // 기존 수수료 (satoshi)
+	ReplacedFee int64   `json:"replacedFee"` // 함께 교체되는 후손 거래(CPFP 등)의 수수료 합계 (satoshi)
+	NewFee      int64   `json:"newFee"`      // 새 수수료 (satoshi)
+	NewFeeRate  float64 `json:"newFeeRate"`  // 실제 적용된 수수료율 (sat/vB)
+
+	Discrepancies []QuorumDiscrepancy `json:"discrepancies"` // 교차 검증 불일치 항목 (교차 검증 모드)
+}
+
+// signalsRBF 거래 입력 중 하나라도 BIP125 교체 가능 신호를 보내는지 확인
+func signalsRBF(tx *wire.MsgTx) bool {
+	for _, txIn := range tx.TxIn {
+		if txIn.Sequence < wire.MaxTxInSequenceNum-1 {
+			return true
+		}
+	}
+	return false
+}
+
+// estimateVSize 서명 전 거래의 가상 크기(vbyte) 추정
+// witness가 비어있는 입력은 P2WPKH 서명(72바이트)과 압축 공개키(33바이트)로 가정
+func estimateVSize(tx *wire.MsgTx) int64 {
+	estimate := tx.Copy()
+	for _, txIn := range estimate.TxIn {
+		if len(txIn.Witness) == 0 {
+			txIn.Witness = wire.TxWitness{make([]byte, 72), make([]byte, 33)}
+		}
+	}
+
+	weight := int64(estimate.SerializeSizeStripped()*3 + estimate.SerializeSize())
+	return (weight + 3) / 4
+}
+
+// txFromDetails API 거래 세부정보로부터 서명되지 않은 wire 거래 재구성
+func txFromDetails(details *TxDetails) (*wire.MsgTx, error) {
+	tx := wire.NewMsgTx(details.Version)
+	tx.LockTime = details.Locktime
+
+	for _, vin := range details.Vin {
+		hash, err := chainhash.NewHashFromStr(vin.TxID)
+		if err != nil {
+			return nil, fmt.Errorf("거래 해시 생성 실패: %v", err)
+		}
+		txIn := wire.NewTxIn(wire.NewOutPoint(hash, vin.Vout), nil, nil)
+		txIn.Sequence = vin.Sequence
+		tx.AddTxIn(txIn)
+	}
+
+	for _, vout := range details.Vout {
+		script, err := hex.DecodeString(vout.ScriptPubKey)
+		if err != nil {
+			return nil, fmt.Errorf("출력 스크립트 디코딩 실패: %v", err)
+		}
+		tx.AddTxOut(wire.NewTxOut(vout.Value, script))
+	}
+
+	return tx, nil
+}
+
+// BumpFee 미확인 거래를 더 높은 수수료율로 재구성, 재서명 후 교체 거래로 브로드캐스트 (BIP125)
+func (a *App) BumpFee(request BumpFeeRequest) BumpFeeResponse {
+	if request.TxID == "" {
+		return BumpFeeResponse{
+			Success: false,
+			Message: "거래 ID를 입력해주세요",
+		}
+	}
+
+	if request.FeeRate <= 0 {
+		return BumpFeeResponse{
+			Success:   false,
+			Message:   "수수료율은 0보다 커야 합니다",
+			ErrorCode: "FEE_RATE_INVALID",
+		}
+	}
+	if request.FeeRate > maxBumpFeeRate {
+		return BumpFeeResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("수수료율이 너무 높습니다. 최대 %.0f sat/vB를 초과할 수 없습니다.", maxBumpFeeRate),
+			ErrorCode: "FEE_RATE_TOO_HIGH",
+		}
+	}
+
+	// 1. 기존 거래 조회
+	details, err := a.fetchTxDetails(request.TxID)
+	if err != nil {
+		return BumpFeeResponse{
+			Success:       false,
+			Message:       fmt.Sprintf("거래 세부정보 조회 실패: %v", err),
+			ErrorCode:     backendErrorCode(err),
+			Discrepancies: quorumDiscrepancies(err),
+		}
+	}
+
+	if details.Status.Confirmed {
+		return BumpFeeResponse{
+			Success:   false,
+			Message:   "이미 확인된 거래는 교체할 수 없습니다",
+			ErrorCode: "TX_ALREADY_CONFIRMED",
+		}
+	}
+
+	// 2. 모든 입력이 이 지갑의 UTXO인지 확인
+	walletAddr, err := btcutil.DecodeAddress(request.WalletData.Address, &chaincfg.MainNetParams)
+	if err != nil {
+		return BumpFeeResponse{
+			Success: false,
+			Message: fmt.Sprintf("지갑 주소 파싱 실패: %v", err),
+		}
+	}
+	walletScript, err := txscript.PayToAddrScript(walletAddr)
+	if err != nil {
+		return BumpFeeResponse{
+			Success: false,
+			Message: fmt.Sprintf("지갑 스크립트 생성 실패: %v", err),
+		}
+	}
+	walletScriptHex := hex.EncodeToString(walletScript)
+
+	var totalInput int64
+	for _, vin := range details.Vin {
+		if vin.Prevout == nil || vin.Prevout.ScriptPubKey != walletScriptHex {
+			return BumpFeeResponse{
+				Success:   false,
+				Message:   "이 지갑에서 보낸 거래가 아닙니다",
+				ErrorCode: "TX_NOT_FROM_WALLET",
+			}
+		}
+		totalInput += vin.Prevout.Value
+	}
+
+	tx, err := txFromDetails(details)
+	if err != nil {
+		return BumpFeeResponse{
+			Success: false,
+			Message: err.Error(),
+		}
+	}
+
+	// 3. BIP125 규칙 1: 기존 거래가 교체 가능 신호를 보내야 함
+	if !signalsRBF(tx) {
+		return BumpFeeResponse{
+			Success:   false,
+			Message:   "RBF 신호가 없는 거래는 교체할 수 없습니다",
+			ErrorCode: "TX_NOT_REPLACEABLE",
+		}
+	}
+
+	// 4. BIP125 규칙 3: 교체 거래는 함께 제거되는 후손 거래의 수수료까지 부담해야 함
+	descendants, err := a.walletDescendants(request.TxID, request.WalletData.Address)
+	if err != nil {
+		return BumpFeeResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("후손 거래를 확인할 수 없어 교체할 수 없습니다: %v", err),
+			ErrorCode: "DESCENDANTS_UNKNOWN",
+		}
+	}
+	var replacedFee int64
+	for _, descendant := range descendants {
+		replacedFee += descendant.Fee
+	}
+
+	// 5. 거스름돈 출력과 나머지 출력 분리 (나머지 출력은 그대로 유지)
+	// 기존 수수료는 백엔드 값 대신 확인한 이전 출력 금액으로 직접 계산
+	oldFee := totalInput
+	for _, txOut := range tx.TxOut {
+		oldFee -= txOut.Value
+	}
+	if oldFee < 0 {
+		return BumpFeeResponse{
+			Success: false,
+			Message: "기존 거래의 출력 합계가 입력 합계보다 큽니다",
+		}
+	}
+	oldVSize := (details.Weight + 3) / 4
+	var fixedOutputs []*wire.TxOut
+	var fixedOutputTotal int64
+	changeIndex := a.changeOutputIndex(request.TxID, tx, walletScript)
+	for i, txOut := range tx.TxOut {
+		if i == changeIndex {
+			continue
+		}
+		fixedOutputs = append(fixedOutputs, txOut)
+		fixedOutputTotal += txOut.Value
+	}
+
+	// 6. 추가 입력 후보: 확인된 UTXO만 사용 (BIP125 규칙 2: 새로운 미확인 입력 금지)
+	spent := make(map[wire.OutPoint]bool)
+	for _, txIn := range tx.TxIn {
+		spent[txIn.PreviousOutPoint] = true
+	}
+	utxos, err := a.fetchUTXOs(request.WalletData.Address)
+	if err != nil {
+		return BumpFeeResponse{
+			Success:       false,
+			Message:       fmt.Sprintf("UTXO 조회 실패: %v", err),
+			ErrorCode:     backendErrorCode(err),
+			Discrepancies: quorumDiscrepancies(err),
+		}
+	}
+	var candidates []UTXO
+	for _, utxo := range utxos {
+		hash, err := chainhash.NewHashFromStr(utxo.TxID)
+		if err != nil || spent[*wire.NewOutPoint(hash, uint32(utxo.Vout))] {
+			continue
+		}
+		candidates = append(candidates, utxo)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Value > candidates[j].Value
+	})
+
+	prevOutScripts := make([][]byte, 0, len(tx.TxIn))
+	prevOutValues := make([]int64, 0, len(tx.TxIn))
+	for _, vin := range details.Vin {
+		prevOutScripts = append(prevOutScripts, walletScript)
+		prevOutValues = append(prevOutValues, vin.Prevout.Value)
+	}
+
+	// 7. 새 수수료 계산: 거스름돈을 줄이고 부족하면 입력 추가
+	var newTx *wire.MsgTx
+	var newFee int64
+	newChangeIndex := -1
+	for {
+		candidate := wire.NewMsgTx(tx.Version)
+		candidate.LockTime = tx.LockTime
+		for _, txIn := range tx.TxIn {
+			newIn := wire.NewTxIn(&txIn.PreviousOutPoint, nil, nil)
+			newIn.Sequence = rbfSequence
+			candidate.AddTxIn(newIn)
+		}
+		for _, txOut := range fixedOutputs {
+			candidate.AddTxOut(wire.NewTxOut(txOut.Value, txOut.PkScript))
+		}
+
+		// 거스름돈 출력이 있는 경우의 크기로 수수료 계산
+		withChange := candidate.Copy()
+		withChange.AddTxOut(wire.NewTxOut(0, walletScript))
+		fee := bumpedFee(oldFee+replacedFee, estimateVSize(withChange), request.FeeRate)
+		change := totalInput - fixedOutputTotal - fee
+		if change >= 546 {
+			withChange.TxOut[len(withChange.TxOut)-1].Value = change
+			newTx, newFee = withChange, fee
+			newChangeIndex = len(withChange.TxOut) - 1
+			break
+		}
+
+		// 거스름돈 없이 수수료를 감당할 수 있으면 거스름돈을 수수료로 포함
+		fee = bumpedFee(oldFee+replacedFee, estimateVSize(candidate), request.FeeRate)
+		if totalInput-fixedOutputTotal >= fee {
+			newTx, newFee = candidate, totalInput-fixedOutputTotal
+			break
+		}
+
+		// 입력 추가
+		if len(candidates) == 0 {
+			return BumpFeeResponse{
+				Success:   false,
+				Message:   fmt.Sprintf("잔액이 부족합니다. 필요 수수료: %d satoshi", fee),
+				ErrorCode: "INSUFFICIENT_FUNDS",
+				OldFee:    oldFee,
+			}
+		}
+		next := candidates[0]
+		candidates = candidates[1:]
+		hash, _ := chainhash.NewHashFromStr(next.TxID)
+		txIn := wire.NewTxIn(wire.NewOutPoint(hash, uint32(next.Vout)), nil, nil)
+		txIn.Sequence = rbfSequence
+		tx.AddTxIn(txIn)
+		totalInput += next.Value
+		prevOutScripts = append(prevOutScripts, walletScript)
+		prevOutValues = append(prevOutValues, next.Value)
+	}
+
+	// 8. BIP125 규칙 3, 4, 6 검증
+	newVSize := estimateVSize(newTx)
+	if newFee <= oldFee+replacedFee || newFee-oldFee-replacedFee < int64(math.Ceil(incrementalRelayFeeRate*float64(newVSize))) {
+		return BumpFeeResponse{
+			Success:   false,
+			Message:   "교체 거래의 수수료가 BIP125 최소 증가량을 충족하지 않습니다",
+			ErrorCode: "BUMP_FEE_TOO_LOW",
+			OldFee:    oldFee,
+		}
+	}
+	if oldVSize > 0 && float64(newFee)/float64(newVSize) <= float64(oldFee)/float64(oldVSize) {
+		return BumpFeeResponse{
+			Success:   false,
+			Message:   "교체 거래의 수수료율이 기존 거래보다 높아야 합니다",
+			ErrorCode: "BUMP_FEE_TOO_LOW",
+			OldFee:    oldFee,
+		}
+	}
+
+	// 9. 재서명
+	privKeyWIF, err := btcutil.DecodeWIF(request.WalletData.PrivateKeyWIF)
+	if err != nil {
+		return BumpFeeResponse{
+			Success: false,
+			Message: fmt.Sprintf("개인키 디코딩 실패: %v", err),
+		}
+	}
+	fetcher, err := a.verifyPrevOuts(newTx, prevOutScripts, prevOutValues)
+	if err != nil {
+		return BumpFeeResponse{
+			Success:   false,
+			Message:   err.Error(),
+			ErrorCode: prevOutErrorCode(err),
+		}
+	}
+	if err := signP2WPKHInputs(newTx, fetcher, privKeyWIF); err != nil {
+		return BumpFeeResponse{
+			Success: false,
+			Message: err.Error(),
+		}
+	}
+
+	// 10. 직렬화 및 브로드캐스트
+	var buf bytes.Buffer
+	if err := newTx.Serialize(&buf); err != nil {
+		return BumpFeeResponse{
+			Success: false,
+			Message: fmt.Sprintf("거래 직렬화 실패: %v", err),
+		}
+	}
+
+	txHash, err := a.broadcastTransaction(hex.EncodeToString(buf.Bytes()))
+	if err != nil {
+		return BumpFeeResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("거래 브로드캐스트 실패: %v", err),
+			ErrorCode: backendErrorCode(err),
+		}
+	}
+
+	a.rememberChange(newTx.TxHash().String(), newChangeIndex)
+
+	return BumpFeeResponse{
+		Success:     true,
+		Message:     "교체 거래가 성공적으로 전송되었습니다",
+		TxHash:      txHash,
+		OldFee:      oldFee,
+		ReplacedFee: replacedFee,
+		NewFee:      newFee,
+		NewFeeRate:  float64(newFee) / float64(newVSize),
+	}
+}
+
+// bumpedFee 목표 수수료율과 BIP125 최소 증가량 중 큰 값으로 새 수수료 계산
+func bumpedFee(oldFee, vsize int64, feeRate float64) int64 {
+	targetFee := int64(math.Ceil(feeRate * float64(vsize)))
+	minFee := oldFee + int64(math.Ceil(incrementalRelayFeeRate*float64(vsize)))
+	if targetFee < minFee {
+		return minFee
+	}
+	return targetFee
+}
+
+// rememberChange 이 앱에서 보낸 거래의 거스름돈 출력 위치 기록
+func (a *App) rememberChange(txid string, index int) {
+	a.pendingMu.Lock()
+	defer a.pendingMu.Unlock()
+	a.sentChange[txid] = index
+}
+
+// changeOutputIndex 거스름돈 출력 위치 (없으면 -1)
+// 이 앱에서 보낸 거래는 거래 계획에 기록된 위치를 사용하고, 그 외에는 거래 계획이 거스름돈을 항상
+// 마지막 출력으로 추가하는 점을 이용해 지갑으로 돌아오는 마지막 출력만 거스름돈으로 봄
+// 출력이 하나뿐이면 지갑으로 보내는 경우라도 결제 출력이므로 거스름돈이 아님
+func (a *App) changeOutputIndex(txid string, tx *wire.MsgTx, walletScript []byte) int {
+	a.pendingMu.Lock()
+	index, ok := a.sentChange[txid]
+	a.pendingMu.Unlock()
+	if ok {
+		return index
+	}
+
+	last := len(tx.TxOut) - 1
+	if last < 1 || !bytes.Equal(tx.TxOut[last].PkScript, walletScript) {
+		return -1
+	}
+	return last
+}
+
+// walletDescendants 교체 대상 거래의 미확인 후손 거래 (지갑 주소의 미확인 거래 중에서 찾음)
+// 교체되면 후손 거래도 멤풀에서 함께 제거되므로 그 수수료도 교체 거래가 부담해야 함
+func (a *App) walletDescendants(txid, address string) ([]TxDetails, error) {
+	txs, err := a.chain().AddressTransactions(address, "")
+	if err != nil {
+		return nil, err
+	}
+
+	replaced := map[string]bool{txid: true}
+	var descendants []TxDetails
+	for found := true; found; {
+		found = false
+		for _, tx := range txs {
+			if tx.Status.Confirmed || replaced[tx.TxID] {
+				continue
+			}
+			for _, vin := range tx.Vin {
+				if replaced[vin.TxID] {
+					replaced[tx.TxID] = true
+					descendants = append(descendants, tx)
+					found = true
+					break
+				}
+			}
+		}
+	}
+	return descendants, nil
+}
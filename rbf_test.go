@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+// utxoErrorBackend UTXO 조회만 실패하는 백엔드
+type utxoErrorBackend struct {
+	*fakeBackend
+	utxoErr error
+}
+
+func (b *utxoErrorBackend) AddressUTXOs(address string) ([]UTXO, error) {
+	if b.utxoErr != nil {
+		return nil, b.utxoErr
+	}
+	return b.fakeBackend.AddressUTXOs(address)
+}
+
+func TestBumpFee(t *testing.T) {
+	walletScript := hex.EncodeToString(walletScriptFromState(&filterState{Address: testWalletAddress}))
+	backend := &utxoErrorBackend{
+		fakeBackend: newFakeBackend(),
+		utxoErr:     &backendError{code: ErrorCodeBackendUnavailable, err: errors.New("connection refused")},
+	}
+	// 백엔드가 알려준 수수료(0)는 쓰지 않고 이전 출력으로 계산하면 10000
+	backend.txs[testTxID(1)] = &TxDetails{
+		TxID:    testTxID(1),
+		Version: 2,
+		Vin: []TxInput{{
+			TxID:     testTxID(2),
+			Prevout:  &TxOutput{ScriptPubKey: walletScript, ScriptPubKeyAddress: testWalletAddress, Value: 100_000},
+			Sequence: rbfSequence,
+		}},
+		Vout: []TxOutput{
+			{ScriptPubKey: "51", Value: 60_000},
+			{ScriptPubKey: walletScript, ScriptPubKeyAddress: testWalletAddress, Value: 30_000},
+		},
+		Weight: 600,
+	}
+	app := newTestApp(backend)
+	request := BumpFeeRequest{WalletData: WalletData{Address: testWalletAddress}, TxID: testTxID(1), FeeRate: 1000}
+
+	// UTXO 조회 실패를 잔액 부족으로 바꾸지 않음
+	if response := app.BumpFee(request); response.Success || response.ErrorCode != ErrorCodeBackendUnavailable {
+		t.Fatalf("BumpFee with failing UTXO lookup = %+v, want %s", response, ErrorCodeBackendUnavailable)
+	}
+
+	backend.utxoErr = nil
+	response := app.BumpFee(request)
+	if response.ErrorCode != "INSUFFICIENT_FUNDS" || response.OldFee != 10_000 {
+		t.Errorf("BumpFee = %+v, want INSUFFICIENT_FUNDS with the locally computed old fee 10000", response)
+	}
+}
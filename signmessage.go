@@ -0,0 +1,398 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// 메시지 서명 형식
+const (
+	SignatureFormatBIP137 = "bip137" // 레거시 압축 서명 (65바이트, Bitcoin Core signmessage 호환)
+	SignatureFormatBIP322 = "bip322" // BIP322 simple 서명 (witness 스택)
+)
+
+const (
+	// bitcoinSignedMessageMagic BIP137 메시지 해시 접두사
+	bitcoinSignedMessageMagic = "Bitcoin Signed Message:\n"
+
+	// bip322MessageTag BIP322 메시지 해시 태그
+	bip322MessageTag = "BIP0322-signed-message"
+)
+
+// BIP137 서명 헤더 바이트 범위 (헤더 - 시작값 = 공개키 복구 ID)
+const (
+	bip137HeaderP2PKHUncompressed = 27
+	bip137HeaderP2PKHCompressed   = 31
+	bip137HeaderP2SHP2WPKH        = 35
+	bip137HeaderP2WPKH            = 39
+	bip137HeaderEnd               = 43
+)
+
+// SignMessageRequest 메시지 서명 요청 구조체 (지갑 파일만으로 오프라인 서명)
+type SignMessageRequest struct {
+	FilePath string `json:"filePath"` // 지갑 파일 경로
+	Password string `json:"password"` // 지갑 비밀번호
+	Message  string `json:"message"`  // 서명할 메시지
+	Format   string `json:"format"`   // 서명 형식 (bip137, bip322, 비어있으면 bip322)
+}
+
+// SignMessageResponse 메시지 서명 응답 구조체
+type SignMessageResponse struct {
+	Success   bool   `json:"success"`   // 성공 여부
+	Message   string `json:"message"`   // 응답 메시지
+	ErrorCode string `json:"errorCode"` // 에러 코드 (다국어 처리용)
+	Address   string `json:"address"`   // 서명한 지갑 주소
+	Signature string `json:"signature"` // 서명 (base64)
+	Format    string `json:"format"`    // 사용한 서명 형식
+}
+
+// VerifyMessageRequest 메시지 서명 검증 요청 구조체
+type VerifyMessageRequest struct {
+	Address   string `json:"address"`   // 서명한 주소
+	Message   string `json:"message"`   // 서명된 메시지
+	Signature string `json:"signature"` // 서명 (base64)
+	Format    string `json:"format"`    // 서명 형식 (bip137, bip322, 비어있으면 서명에서 자동 판별)
+}
+
+// VerifyMessageResponse 메시지 서명 검증 응답 구조체
+type VerifyMessageResponse struct {
+	Success   bool   `json:"success"`   // 검증 수행 여부
+	Message   string `json:"message"`   // 응답 메시지
+	ErrorCode string `json:"errorCode"` // 에러 코드 (다국어 처리용)
+	Valid     bool   `json:"valid"`     // 서명 유효 여부
+	Format    string `json:"format"`    // 검증에 사용한 서명 형식
+}
+
+// bip137MessageHash 레거시 메시지 해시 (접두사와 메시지를 각각 길이와 함께 이중 SHA256)
+func bip137MessageHash(message string) []byte {
+	var buf bytes.Buffer
+	wire.WriteVarString(&buf, 0, bitcoinSignedMessageMagic)
+	wire.WriteVarString(&buf, 0, message)
+	return chainhash.DoubleHashB(buf.Bytes())
+}
+
+// signBIP137 BIP137 압축 서명 생성 (헤더 바이트로 주소 종류를 표시)
+func signBIP137(privKey *btcec.PrivateKey, address btcutil.Address, message string) ([]byte, error) {
+	signature, err := ecdsa.SignCompact(privKey, bip137MessageHash(message), true)
+	if err != nil {
+		return nil, err
+	}
+
+	// SignCompact는 압축 P2PKH 헤더(31-34)를 사용하므로 주소 종류에 맞게 조정
+	switch address.(type) {
+	case *btcutil.AddressPubKeyHash:
+	case *btcutil.AddressWitnessPubKeyHash:
+		signature[0] += bip137HeaderP2WPKH - bip137HeaderP2PKHCompressed
+	case *btcutil.AddressScriptHash:
+		signature[0] += bip137HeaderP2SHP2WPKH - bip137HeaderP2PKHCompressed
+	default:
+		return nil, fmt.Errorf("BIP137 서명을 지원하지 않는 주소 종류입니다")
+	}
+	return signature, nil
+}
+
+// verifyBIP137 BIP137 압축 서명 검증
+// 세그윗 주소를 압축 P2PKH 헤더로 서명하는 지갑(Electrum 등)도 있으므로 압축 공개키는 주소 종류와 관계없이 비교함
+func verifyBIP137(address btcutil.Address, message string, signature []byte) (bool, error) {
+	if len(signature) != 65 {
+		return false, fmt.Errorf("BIP137 서명은 65바이트여야 합니다")
+	}
+	header := signature[0]
+	if header < bip137HeaderP2PKHUncompressed || header >= bip137HeaderEnd {
+		return false, fmt.Errorf("잘못된 BIP137 서명 헤더: %d", header)
+	}
+
+	// 공개키 복구는 P2PKH 헤더 범위(27-34)만 받으므로 세그윗 헤더를 압축 헤더로 변환
+	normalized := append([]byte{}, signature...)
+	if header >= bip137HeaderP2SHP2WPKH {
+		normalized[0] = bip137HeaderP2PKHCompressed + (header-bip137HeaderP2SHP2WPKH)%4
+	}
+	pubKey, compressed, err := ecdsa.RecoverCompact(normalized, bip137MessageHash(message))
+	if err != nil {
+		return false, nil
+	}
+
+	var serialized []byte
+	if compressed {
+		serialized = pubKey.SerializeCompressed()
+	} else {
+		serialized = pubKey.SerializeUncompressed()
+	}
+	keyHash := btcutil.Hash160(serialized)
+
+	switch addr := address.(type) {
+	case *btcutil.AddressPubKeyHash:
+		return bytes.Equal(addr.Hash160()[:], keyHash), nil
+	case *btcutil.AddressWitnessPubKeyHash:
+		return compressed && bytes.Equal(addr.Hash160()[:], keyHash), nil
+	case *btcutil.AddressScriptHash:
+		// P2SH-P2WPKH: 스크립트는 OP_0 <20바이트 공개키 해시>
+		redeemScript := append([]byte{txscript.OP_0, txscript.OP_DATA_20}, keyHash...)
+		return compressed && bytes.Equal(addr.Hash160()[:], btcutil.Hash160(redeemScript)), nil
+	}
+	return false, fmt.Errorf("BIP137 서명을 지원하지 않는 주소 종류입니다")
+}
+
+// bip322Transactions BIP322 가상 거래 (to_spend, to_sign) 구성
+func bip322Transactions(pkScript []byte, message string) (*wire.MsgTx, *wire.MsgTx, error) {
+	messageHash := chainhash.TaggedHash([]byte(bip322MessageTag), []byte(message))
+	scriptSig, err := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_0).
+		AddData(messageHash[:]).
+		Script()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	toSpend := wire.NewMsgTx(0)
+	toSpend.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Index: wire.MaxPrevOutIndex},
+		SignatureScript:  scriptSig,
+		Sequence:         0,
+	})
+	toSpend.AddTxOut(wire.NewTxOut(0, pkScript))
+
+	toSign := wire.NewMsgTx(0)
+	toSign.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: toSpend.TxHash(), Index: 0},
+		Sequence:         0,
+	})
+	toSign.AddTxOut(wire.NewTxOut(0, []byte{txscript.OP_RETURN}))
+	return toSpend, toSign, nil
+}
+
+// encodeWitness witness 스택 직렬화 (항목 수 + 길이가 붙은 각 항목)
+func encodeWitness(witness wire.TxWitness) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := wire.WriteVarInt(&buf, 0, uint64(len(witness))); err != nil {
+		return nil, err
+	}
+	for _, item := range witness {
+		if err := wire.WriteVarBytes(&buf, 0, item); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeWitness 직렬화된 witness 스택 파싱 (남는 바이트가 있으면 오류)
+func decodeWitness(data []byte) (wire.TxWitness, error) {
+	reader := bytes.NewReader(data)
+	count, err := wire.ReadVarInt(reader, 0)
+	if err != nil {
+		return nil, err
+	}
+	if count > uint64(len(data)) {
+		return nil, fmt.Errorf("witness 항목 수가 너무 큽니다")
+	}
+
+	witness := make(wire.TxWitness, count)
+	for i := range witness {
+		witness[i], err = wire.ReadVarBytes(reader, 0, uint32(len(data)), "witness item")
+		if err != nil {
+			return nil, err
+		}
+	}
+	if reader.Len() != 0 {
+		return nil, fmt.Errorf("witness 뒤에 불필요한 데이터가 있습니다")
+	}
+	return witness, nil
+}
+
+// signBIP322 BIP322 simple 서명 생성 (지갑의 P2WPKH 주소)
+func signBIP322(privKey *btcec.PrivateKey, pkScript []byte, message string) ([]byte, error) {
+	toSpend, toSign, err := bip322Transactions(pkScript, message)
+	if err != nil {
+		return nil, err
+	}
+
+	fetcher := txscript.NewCannedPrevOutputFetcher(pkScript, 0)
+	sigHashes := txscript.NewTxSigHashes(toSign, fetcher)
+	witness, err := txscript.WitnessSignature(toSign, sigHashes, 0, toSpend.TxOut[0].Value,
+		pkScript, txscript.SigHashAll, privKey, true)
+	if err != nil {
+		return nil, err
+	}
+	return encodeWitness(witness)
+}
+
+// verifyBIP322 BIP322 simple 서명 검증 (스크립트 엔진으로 to_sign 거래 실행, P2WPKH/P2TR 등 세그윗 주소)
+func verifyBIP322(address btcutil.Address, message string, signature []byte) (bool, error) {
+	switch address.(type) {
+	case *btcutil.AddressWitnessPubKeyHash, *btcutil.AddressWitnessScriptHash, *btcutil.AddressTaproot:
+	default:
+		return false, fmt.Errorf("BIP322 simple 서명은 세그윗 주소만 지원합니다")
+	}
+
+	witness, err := decodeWitness(signature)
+	if err != nil {
+		return false, fmt.Errorf("잘못된 BIP322 서명: %v", err)
+	}
+
+	pkScript, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		return false, err
+	}
+	toSpend, toSign, err := bip322Transactions(pkScript, message)
+	if err != nil {
+		return false, err
+	}
+	toSign.TxIn[0].Witness = witness
+
+	fetcher := txscript.NewCannedPrevOutputFetcher(pkScript, 0)
+	engine, err := txscript.NewEngine(pkScript, toSign, 0, txscript.StandardVerifyFlags, nil,
+		txscript.NewTxSigHashes(toSign, fetcher), toSpend.TxOut[0].Value, fetcher)
+	if err != nil {
+		return false, nil
+	}
+	return engine.Execute() == nil, nil
+}
+
+// detectSignatureFormat 서명 바이트로 형식 판별 (BIP137 헤더로 시작하는 65바이트면 BIP137)
+func detectSignatureFormat(signature []byte) string {
+	if len(signature) == 65 && signature[0] >= bip137HeaderP2PKHUncompressed && signature[0] < bip137HeaderEnd {
+		return SignatureFormatBIP137
+	}
+	return SignatureFormatBIP322
+}
+
+// SignMessage 지갑 키로 메시지 서명 (네트워크 연결 없이 지갑 파일만 사용)
+func (a *App) SignMessage(request SignMessageRequest) SignMessageResponse {
+	format := strings.ToLower(request.Format)
+	if format == "" {
+		format = SignatureFormatBIP322
+	}
+	if format != SignatureFormatBIP137 && format != SignatureFormatBIP322 {
+		return SignMessageResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("지원되지 않는 서명 형식: %s", request.Format),
+			ErrorCode: "SIGNATURE_FORMAT_INVALID",
+		}
+	}
+
+	fileData, err := os.ReadFile(request.FilePath)
+	if err != nil {
+		return SignMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("지갑 파일을 읽을 수 없습니다: %v", err),
+		}
+	}
+	walletData, err := a.decryptColdWallet(fileData, request.Password)
+	if err != nil {
+		return SignMessageResponse{
+			Success:   false,
+			Message:   "잘못된 비밀번호이거나 손상된 지갑 파일입니다",
+			ErrorCode: "WALLET_DECRYPT_FAILED",
+		}
+	}
+
+	wif, err := btcutil.DecodeWIF(walletData.PrivateKeyWIF)
+	if err != nil {
+		return SignMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("개인키 디코딩 실패: %v", err),
+		}
+	}
+	address, err := btcutil.DecodeAddress(walletData.Address, &chaincfg.MainNetParams)
+	if err != nil {
+		return SignMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("지갑 주소 디코딩 실패: %v", err),
+		}
+	}
+	pkScript, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		return SignMessageResponse{
+			Success: false,
+			Message: fmt.Sprintf("지갑 주소 스크립트 생성 실패: %v", err),
+		}
+	}
+
+	var signature []byte
+	if format == SignatureFormatBIP137 {
+		signature, err = signBIP137(wif.PrivKey, address, request.Message)
+	} else {
+		signature, err = signBIP322(wif.PrivKey, pkScript, request.Message)
+	}
+	if err != nil {
+		return SignMessageResponse{
+			Success:   false,
+			Message:   fmt.Sprintf("메시지 서명 실패: %v", err),
+			ErrorCode: "MESSAGE_SIGN_FAILED",
+		}
+	}
+
+	return SignMessageResponse{
+		Success:   true,
+		Message:   "메시지가 서명되었습니다",
+		Address:   walletData.Address,
+		Signature: base64.StdEncoding.EncodeToString(signature),
+		Format:    format,
+	}
+}
+
+// VerifyMessage 주소의 메시지 서명 검증 (BIP137, BIP322 simple)
+func (a *App) VerifyMessage(request VerifyMessageRequest) VerifyMessageResponse {
+	address, err := btcutil.DecodeAddress(strings.TrimSpace(request.Address), &chaincfg.MainNetParams)
+	if err != nil || !address.IsForNet(&chaincfg.MainNetParams) {
+		return VerifyMessageResponse{
+			Success:   false,
+			Message:   "올바른 비트코인 주소가 아닙니다",
+			ErrorCode: "INVALID_ADDRESS",
+		}
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(request.Signature))
+	if err != nil || len(signature) == 0 {
+		return VerifyMessageResponse{
+			Success:   false,
+			Message:   "서명은 base64 형식이어야 합니다",
+			ErrorCode: "SIGNATURE_MALFORMED",
+		}
+	}
+
+	format := strings.ToLower(request.Format)
+	if format == "" {
+		format = detectSignatureFormat(signature)
+	}
+
+	var valid bool
+	switch format {
+	case SignatureFormatBIP137:
+		valid, err = verifyBIP137(address, request.Message, signature)
+	case SignatureFormatBIP322:
+		valid, err = verifyBIP322(address, request.Message, signature)
+	default:
+		err = fmt.Errorf("지원되지 않는 서명 형식: %s", request.Format)
+	}
+	if err != nil {
+		return VerifyMessageResponse{
+			Success:   false,
+			Message:   err.Error(),
+			ErrorCode: "SIGNATURE_MALFORMED",
+			Format:    format,
+		}
+	}
+
+	message := "서명이 유효합니다"
+	if !valid {
+		message = "서명이 주소 또는 메시지와 일치하지 않습니다"
+	}
+	return VerifyMessageResponse{
+		Success: true,
+		Message: message,
+		Valid:   valid,
+		Format:  format,
+	}
+}
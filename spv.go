@@ -0,0 +1,646 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcd/blockchain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	// spvHeadersFileName 동기화한 블록 헤더를 저장하는 파일 이름 (설정 파일과 같은 폴더)
+	spvHeadersFileName = "wallet-headers.dat"
+
+	// retargetInterval 난이도 조정 주기 (블록 수)
+	retargetInterval = 2016
+
+	// targetTimespan 난이도 조정 주기의 목표 시간 (2주)
+	targetTimespan = int64(14 * 24 * 60 * 60)
+
+	// headerSyncBatch 한 번에 요청할 헤더 수
+	headerSyncBatch = 2016
+
+	// reorgStepBack 체인 재구성 시 한 번에 되돌릴 헤더 수
+	reorgStepBack = 6
+
+	// maxReorgDepth 허용하는 최대 체인 재구성 깊이
+	maxReorgDepth = 100
+
+	// medianTimeBlocks 중간 시간 계산에 사용하는 이전 블록 수
+	medianTimeBlocks = 11
+)
+
+// ErrorCodeSPVVerification 블록 헤더 또는 Merkle 증명 검증 실패 에러 코드
+const ErrorCodeSPVVerification = "SPV_VERIFICATION_FAILED"
+
+// errSPVVerification SPV 검증 실패 (헤더 체인 또는 Merkle 증명이 맞지 않음)
+var errSPVVerification = errors.New("SPV 검증 실패")
+
+// errHeaderNotConnected 새 헤더가 현재 헤더 체인의 끝에 이어지지 않음 (체인 재구성 가능성)
+var errHeaderNotConnected = errors.New("헤더가 현재 체인에 연결되지 않습니다")
+
+// SPVConfig SPV 검증 설정
+type SPVConfig struct {
+	Enabled     bool   `json:"enabled"`     // 확인된 UTXO/거래를 블록 헤더와 Merkle 증명으로 검증
+	HeadersFile string `json:"headersFile"` // 제네시스부터 80바이트 헤더를 이어 붙인 파일 (선택, 없으면 체크포인트부터 동기화, Esplora 백엔드는 처음 동기화할 때 필요)
+}
+
+// headerSource 블록 헤더를 제공하는 백엔드
+type headerSource interface {
+	// BlockHeaders startHeight부터 최대 count개의 헤더를 높이 순서대로 반환
+	BlockHeaders(startHeight int64, count int) ([]wire.BlockHeader, error)
+}
+
+// merkleProof 거래의 Merkle 포함 증명 (Esplora/Electrum 형식)
+type merkleProof struct {
+	BlockHeight int64    `json:"block_height"`
+	Merkle      []string `json:"merkle"`
+	Pos         int      `json:"pos"`
+}
+
+// merkleProofSource Merkle 포함 증명을 제공하는 백엔드
+type merkleProofSource interface {
+	// MerkleProof 거래가 height 블록에 포함되었다는 증명 조회
+	MerkleProof(txid string, height int64) (*merkleProof, error)
+	// CoinbaseProof height 블록(blockHash)의 코인베이스 원본 거래와 포함 증명 조회 (트리 깊이 확인용)
+	CoinbaseProof(height int64, blockHash chainhash.Hash) ([]byte, *merkleProof, error)
+}
+
+// headerChain 검증된 블록 헤더 체인 (작업 증명, 난이도 조정, 체크포인트 확인)
+// 헤더 파일이 없으면 마지막 체크포인트가 속한 난이도 주기의 첫 블록부터 시작하며,
+// 시작 헤더는 체크포인트까지 이어지는 해시 체인으로 인증됨
+type headerChain struct {
+	mu        sync.Mutex
+	path      string
+	base      int64
+	headers   []wire.BlockHeader
+	hashes    []chainhash.Hash
+	persisted int // 파일에 저장된 헤더 수
+}
+
+// headersStorePath 헤더 저장 파일 경로 (실행 파일과 같은 폴더)
+func headersStorePath() string {
+	return filepath.Join(filepath.Dir(configFilePath()), spvHeadersFileName)
+}
+
+// lastCheckpoint 메인넷 마지막 체크포인트
+func lastCheckpoint() chaincfg.Checkpoint {
+	checkpoints := chaincfg.MainNetParams.Checkpoints
+	return checkpoints[len(checkpoints)-1]
+}
+
+// loadHeaderChain 저장된 헤더 체인을 불러옴 (없으면 헤더 파일 가져오기 또는 체크포인트부터 새로 시작)
+func loadHeaderChain(storePath, importFile string) (*headerChain, error) {
+	// 1. 이전에 동기화한 헤더 (손상되었거나 체크포인트로 인증할 수 없는 시작점이면 버리고 새로 시작)
+	if data, err := os.ReadFile(storePath); err == nil && len(data) >= 8 {
+		base := int64(binary.LittleEndian.Uint64(data[:8]))
+		chain := &headerChain{path: storePath, base: base}
+		if base <= int64(lastCheckpoint().Height) && chain.connectRaw(data[8:]) == nil && len(chain.headers) > 0 {
+			chain.persisted = len(chain.headers)
+			return chain, nil
+		}
+	}
+
+	// 2. 제네시스부터의 헤더 파일
+	if importFile != "" {
+		data, err := os.ReadFile(importFile)
+		if err != nil {
+			return nil, fmt.Errorf("헤더 파일 읽기 실패: %v", err)
+		}
+		chain := &headerChain{path: storePath, base: 0}
+		if err := chain.connectRaw(data); err != nil {
+			return nil, fmt.Errorf("헤더 파일 검증 실패: %w", err)
+		}
+		if err := chain.persist(); err != nil {
+			return nil, err
+		}
+		return chain, nil
+	}
+
+	// 3. 마지막 체크포인트가 속한 난이도 주기의 시작부터
+	checkpoint := lastCheckpoint()
+	base := checkpoint.Height / retargetInterval * retargetInterval
+	return &headerChain{path: storePath, base: int64(base)}, nil
+}
+
+// connectRaw 80바이트 헤더를 이어 붙인 데이터를 체인에 연결
+func (c *headerChain) connectRaw(data []byte) error {
+	if len(data)%wire.MaxBlockHeaderPayload != 0 {
+		return fmt.Errorf("헤더 데이터 길이가 올바르지 않습니다")
+	}
+	reader := bytes.NewReader(data)
+	for reader.Len() > 0 {
+		var header wire.BlockHeader
+		if err := header.Deserialize(reader); err != nil {
+			return fmt.Errorf("헤더 파싱 실패: %v", err)
+		}
+		if err := c.connect(header); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tipHeight 검증된 마지막 헤더의 높이 (없으면 base-1)
+func (c *headerChain) tipHeight() int64 {
+	return c.base + int64(len(c.headers)) - 1
+}
+
+// header 높이에 해당하는 검증된 헤더
+func (c *headerChain) header(height int64) (*wire.BlockHeader, chainhash.Hash, bool) {
+	index := height - c.base
+	if index < 0 || index >= int64(len(c.headers)) {
+		return nil, chainhash.Hash{}, false
+	}
+	return &c.headers[index], c.hashes[index], true
+}
+
+// medianTimePast 마지막 11개 블록 시간의 중간값
+func (c *headerChain) medianTimePast() time.Time {
+	count := medianTimeBlocks
+	if len(c.headers) < count {
+		count = len(c.headers)
+	}
+	times := make([]int64, 0, count)
+	for _, header := range c.headers[len(c.headers)-count:] {
+		times = append(times, header.Timestamp.Unix())
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	return time.Unix(times[len(times)/2], 0)
+}
+
+// nextRequiredBits 난이도 조정 블록에 요구되는 난이도 (Bitcoin Core와 같은 계산)
+func nextRequiredBits(first, last *wire.BlockHeader) uint32 {
+	actual := last.Timestamp.Unix() - first.Timestamp.Unix()
+	if actual < targetTimespan/4 {
+		actual = targetTimespan / 4
+	}
+	if actual > targetTimespan*4 {
+		actual = targetTimespan * 4
+	}
+
+	target := blockchain.CompactToBig(last.Bits)
+	target.Mul(target, big.NewInt(actual))
+	target.Div(target, big.NewInt(targetTimespan))
+	if target.Cmp(chaincfg.MainNetParams.PowLimit) > 0 {
+		target.Set(chaincfg.MainNetParams.PowLimit)
+	}
+	return blockchain.BigToCompact(target)
+}
+
+// connect 헤더를 검증하여 체인 끝에 추가
+func (c *headerChain) connect(header wire.BlockHeader) error {
+	height := c.tipHeight() + 1
+	hash := header.BlockHash()
+
+	if len(c.headers) > 0 {
+		if header.PrevBlock != c.hashes[len(c.hashes)-1] {
+			return errHeaderNotConnected
+		}
+	} else if height == 0 && hash != *chaincfg.MainNetParams.GenesisHash {
+		return fmt.Errorf("%w: 제네시스 블록이 아닙니다", errSPVVerification)
+	}
+
+	// 작업 증명
+	target := blockchain.CompactToBig(header.Bits)
+	if target.Sign() <= 0 || target.Cmp(chaincfg.MainNetParams.PowLimit) > 0 {
+		return fmt.Errorf("%w: 높이 %d의 난이도 값이 범위를 벗어났습니다", errSPVVerification, height)
+	}
+	if blockchain.HashToBig(&hash).Cmp(target) > 0 {
+		return fmt.Errorf("%w: 높이 %d의 작업 증명이 부족합니다", errSPVVerification, height)
+	}
+
+	// 난이도 조정 규칙과 시간
+	if len(c.headers) > 0 {
+		prev := &c.headers[len(c.headers)-1]
+		if height%retargetInterval != 0 {
+			if header.Bits != prev.Bits {
+				return fmt.Errorf("%w: 높이 %d의 난이도가 바뀌었습니다", errSPVVerification, height)
+			}
+		} else if first, _, ok := c.header(height - retargetInterval); ok {
+			if expected := nextRequiredBits(first, prev); header.Bits != expected {
+				return fmt.Errorf("%w: 높이 %d의 난이도 조정 값이 올바르지 않습니다", errSPVVerification, height)
+			}
+		}
+		if !header.Timestamp.After(c.medianTimePast()) {
+			return fmt.Errorf("%w: 높이 %d의 블록 시간이 너무 이릅니다", errSPVVerification, height)
+		}
+	}
+
+	// 체크포인트
+	for _, checkpoint := range chaincfg.MainNetParams.Checkpoints {
+		if int64(checkpoint.Height) == height && *checkpoint.Hash != hash {
+			return fmt.Errorf("%w: 높이 %d가 체크포인트와 다릅니다", errSPVVerification, height)
+		}
+	}
+
+	c.headers = append(c.headers, header)
+	c.hashes = append(c.hashes, hash)
+	return nil
+}
+
+// rollback 마지막 count개 헤더 제거 (체크포인트 이전으로는 되돌리지 않음)
+func (c *headerChain) rollback(count int) bool {
+	keep := len(c.headers) - count
+	if c.base+int64(keep) <= int64(lastCheckpoint().Height) {
+		return false
+	}
+	c.headers = c.headers[:keep]
+	c.hashes = c.hashes[:keep]
+	return true
+}
+
+// sync target 높이까지 헤더를 받아 검증 (체인 재구성은 maxReorgDepth까지 처리)
+func (c *headerChain) sync(source headerSource, target int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rolledBack := 0
+	for c.tipHeight() < target {
+		start := c.tipHeight() + 1
+		count := headerSyncBatch
+		if remaining := target - start + 1; remaining < int64(count) {
+			count = int(remaining)
+		}
+
+		headers, err := source.BlockHeaders(start, count)
+		if err != nil {
+			// 그때까지 검증한 헤더는 저장해 두고 다음 동기화에서 이어서 받음
+			if len(c.headers) > c.persisted {
+				c.persist()
+			}
+			return fmt.Errorf("블록 헤더 조회 실패: %w", err)
+		}
+		if len(headers) == 0 {
+			break
+		}
+
+		for _, header := range headers {
+			err := c.connect(header)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, errHeaderNotConnected) {
+				return err
+			}
+			// 서버 체인이 재구성되었으면 몇 블록 되돌린 뒤 다시 받음
+			if rolledBack >= maxReorgDepth || !c.rollback(reorgStepBack) {
+				return fmt.Errorf("%w: 헤더 체인 재구성이 너무 깊습니다", errSPVVerification)
+			}
+			rolledBack += reorgStepBack
+			break
+		}
+		if err := c.persist(); err != nil {
+			return err
+		}
+	}
+
+	if c.tipHeight() < int64(lastCheckpoint().Height) {
+		return fmt.Errorf("%w: 헤더가 마지막 체크포인트까지 동기화되지 않았습니다", errSPVVerification)
+	}
+
+	return c.persist()
+}
+
+// persist 새로 검증한 헤더를 파일에 추가 (되돌린 헤더가 있으면 파일도 잘라냄)
+func (c *headerChain) persist() error {
+	file, err := os.OpenFile(c.path, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return fmt.Errorf("헤더 파일 저장 실패: %v", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("헤더 파일 저장 실패: %v", err)
+	}
+
+	if info.Size() < 8 || c.persisted > len(c.headers) {
+		if c.persisted > len(c.headers) {
+			c.persisted = len(c.headers)
+		} else {
+			c.persisted = 0
+		}
+		var prefix [8]byte
+		binary.LittleEndian.PutUint64(prefix[:], uint64(c.base))
+		if _, err := file.WriteAt(prefix[:], 0); err != nil {
+			return fmt.Errorf("헤더 파일 저장 실패: %v", err)
+		}
+	}
+
+	offset := int64(8 + c.persisted*wire.MaxBlockHeaderPayload)
+	if err := file.Truncate(offset); err != nil {
+		return fmt.Errorf("헤더 파일 저장 실패: %v", err)
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf("헤더 파일 저장 실패: %v", err)
+	}
+
+	var buf bytes.Buffer
+	for _, header := range c.headers[c.persisted:] {
+		if err := header.Serialize(&buf); err != nil {
+			return err
+		}
+	}
+	if _, err := file.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("헤더 파일 저장 실패: %v", err)
+	}
+
+	c.persisted = len(c.headers)
+	return nil
+}
+
+// verifyMerkleProof Merkle 증명으로 계산한 루트가 블록 헤더의 Merkle 루트와 같은지 확인
+// 모든 거래는 트리의 같은 깊이에 있으므로 경로 길이가 depth와 달라야 하는 증명은 거부함
+// (64바이트 거래를 내부 노드로 해석해 한 단계 더 깊은 가짜 거래를 증명하는 공격 방지)
+func verifyMerkleProof(txid string, proof *merkleProof, root chainhash.Hash, depth int) error {
+	hash, err := chainhash.NewHashFromStr(txid)
+	if err != nil {
+		return fmt.Errorf("잘못된 거래 ID: %v", err)
+	}
+	if len(proof.Merkle) != depth {
+		return fmt.Errorf("%w: 거래 %s의 Merkle 경로 길이(%d)가 블록의 트리 깊이(%d)와 다릅니다",
+			errSPVVerification, txid, len(proof.Merkle), depth)
+	}
+
+	current := *hash
+	pos := proof.Pos
+	for _, siblingHex := range proof.Merkle {
+		sibling, err := chainhash.NewHashFromStr(siblingHex)
+		if err != nil {
+			return fmt.Errorf("%w: 잘못된 Merkle 경로", errSPVVerification)
+		}
+		var pair [chainhash.HashSize * 2]byte
+		if pos&1 == 0 {
+			copy(pair[:chainhash.HashSize], current[:])
+			copy(pair[chainhash.HashSize:], sibling[:])
+		} else {
+			copy(pair[:chainhash.HashSize], sibling[:])
+			copy(pair[chainhash.HashSize:], current[:])
+		}
+		current = chainhash.DoubleHashH(pair[:])
+		pos >>= 1
+	}
+
+	if pos != 0 || current != root {
+		return fmt.Errorf("%w: 거래 %s가 블록에 포함되어 있지 않습니다", errSPVVerification, txid)
+	}
+	return nil
+}
+
+// coinbaseTreeDepth 코인베이스 거래의 포함 증명으로 블록의 Merkle 트리 깊이 확인
+// 코인베이스는 항상 첫 번째 거래이고, 원본 거래가 코인베이스인지 직접 확인하므로 깊이를 속일 수 없음
+func coinbaseTreeDepth(raw []byte, proof *merkleProof, root chainhash.Hash) (int, error) {
+	tx, txid, err := parseRawTransaction(raw)
+	if err != nil {
+		return 0, fmt.Errorf("코인베이스 거래 파싱 실패: %w", err)
+	}
+	if proof.Pos != 0 || !blockchain.IsCoinBaseTx(tx) {
+		return 0, fmt.Errorf("%w: 블록의 첫 거래가 코인베이스가 아닙니다", errSPVVerification)
+	}
+	if err := verifyMerkleProof(txid, proof, root, len(proof.Merkle)); err != nil {
+		return 0, err
+	}
+	return len(proof.Merkle), nil
+}
+
+// spvBackend 확인된 UTXO와 거래를 검증된 헤더 체인과 Merkle 증명으로 확인하는 백엔드
+type spvBackend struct {
+	ChainBackend
+	chain   *headerChain
+	headers headerSource
+	proofs  merkleProofSource
+
+	mu       sync.Mutex
+	verified map[string]int64       // 검증을 마친 거래 ID와 블록 높이
+	depths   map[chainhash.Hash]int // 블록별 Merkle 트리 깊이
+}
+
+// newSPVBackend SPV 검증 백엔드 생성 (헤더와 증명은 source에서 조회)
+func newSPVBackend(backend, source ChainBackend, config SPVConfig) (*spvBackend, error) {
+	headers, ok := source.(headerSource)
+	if !ok {
+		return nil, fmt.Errorf("%s 백엔드는 블록 헤더 조회를 지원하지 않습니다", source.Name())
+	}
+	proofs, ok := source.(merkleProofSource)
+	if !ok {
+		return nil, fmt.Errorf("%s 백엔드는 Merkle 증명 조회를 지원하지 않습니다", source.Name())
+	}
+
+	chain, err := loadHeaderChain(headersStorePath(), config.HeadersFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// Esplora는 요청당 헤더 10개만 주므로 체크포인트부터 처음 동기화하려면 수만 번 요청해야 함
+	if _, ok := headers.(*esploraBackend); ok && len(chain.headers) == 0 {
+		return nil, fmt.Errorf("Esplora 백엔드로 SPV 검증을 사용하려면 헤더 파일을 지정하거나 Electrum 백엔드를 사용해주세요")
+	}
+
+	return &spvBackend{
+		ChainBackend: backend,
+		chain:        chain,
+		headers:      headers,
+		proofs:       proofs,
+		verified:     make(map[string]int64),
+		depths:       make(map[chainhash.Hash]int),
+	}, nil
+}
+
+// treeDepth 블록의 Merkle 트리 깊이 (코인베이스 증명으로 확인 후 캐시)
+func (s *spvBackend) treeDepth(height int64, blockHash, root chainhash.Hash) (int, error) {
+	s.mu.Lock()
+	depth, ok := s.depths[blockHash]
+	s.mu.Unlock()
+	if ok {
+		return depth, nil
+	}
+
+	raw, proof, err := s.proofs.CoinbaseProof(height, blockHash)
+	if err != nil {
+		return 0, fmt.Errorf("코인베이스 증명 조회 실패: %w", err)
+	}
+	depth, err = coinbaseTreeDepth(raw, proof, root)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	s.depths[blockHash] = depth
+	s.mu.Unlock()
+	return depth, nil
+}
+
+// verifyInclusion 거래가 height 블록에 포함되었는지 헤더 체인으로 검증
+func (s *spvBackend) verifyInclusion(txid string, height int64) error {
+	s.mu.Lock()
+	verifiedHeight, ok := s.verified[txid]
+	s.mu.Unlock()
+	if ok && verifiedHeight == height {
+		return nil
+	}
+
+	s.chain.mu.Lock()
+	synced := s.chain.tipHeight()
+	s.chain.mu.Unlock()
+	if synced < height {
+		tip, err := s.ChainBackend.TipHeight()
+		if err != nil {
+			return err
+		}
+		if tip < height {
+			tip = height
+		}
+		if err := s.chain.sync(s.headers, tip); err != nil {
+			return err
+		}
+	}
+
+	s.chain.mu.Lock()
+	header, blockHash, ok := s.chain.header(height)
+	var root chainhash.Hash
+	if ok {
+		root = header.MerkleRoot
+	}
+	s.chain.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: 높이 %d의 헤더가 없습니다", errSPVVerification, height)
+	}
+
+	depth, err := s.treeDepth(height, blockHash, root)
+	if err != nil {
+		return err
+	}
+
+	proof, err := s.proofs.MerkleProof(txid, height)
+	if err != nil {
+		return fmt.Errorf("Merkle 증명 조회 실패: %w", err)
+	}
+	if proof.BlockHeight != height {
+		return fmt.Errorf("%w: 거래 %s의 블록 높이가 다릅니다 (%d, 증명: %d)", errSPVVerification, txid, height, proof.BlockHeight)
+	}
+	if err := verifyMerkleProof(txid, proof, root, depth); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.verified[txid] = height
+	s.mu.Unlock()
+	return nil
+}
+
+// AddressUTXOs 주소의 모든 UTXO 조회 (확인된 UTXO는 모두 SPV 검증)
+func (s *spvBackend) AddressUTXOs(address string) ([]UTXO, error) {
+	utxos, err := s.ChainBackend.AddressUTXOs(address)
+	if err != nil {
+		return nil, err
+	}
+
+	heights := make(map[string]int64)
+	var txids []string
+	for _, utxo := range utxos {
+		if utxo.Status.Confirmed {
+			heights[utxo.TxID] = utxo.Status.BlockHeight
+			txids = append(txids, utxo.TxID)
+		}
+	}
+
+	if _, err := fetchConcurrently(txids, func(txid string) (struct{}, error) {
+		return struct{}{}, s.verifyInclusion(txid, heights[txid])
+	}); err != nil {
+		return nil, err
+	}
+
+	return utxos, nil
+}
+
+// Transaction 거래 세부정보 조회 (확인된 거래는 SPV 검증)
+func (s *spvBackend) Transaction(txid string) (*TxDetails, error) {
+	details, err := s.ChainBackend.Transaction(txid)
+	if err != nil {
+		return nil, err
+	}
+
+	if details.Status.Confirmed {
+		if err := s.verifyInclusion(txid, details.Status.BlockHeight); err != nil {
+			return nil, err
+		}
+	}
+
+	return details, nil
+}
+
+// AddressTransactions 주소의 거래 내역 한 페이지 조회 (확인된 거래는 모두 SPV 검증)
+func (s *spvBackend) AddressTransactions(address, lastSeenTxID string) ([]TxDetails, error) {
+	txs, err := s.ChainBackend.AddressTransactions(address, lastSeenTxID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tx := range txs {
+		if tx.Status.Confirmed {
+			if err := s.verifyInclusion(tx.TxID, tx.Status.BlockHeight); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return txs, nil
+}
+
+// SPVConfigResponse SPV 설정 응답 구조체
+type SPVConfigResponse struct {
+	Success   bool      `json:"success"`   // 성공 여부
+	Message   string    `json:"message"`   // 응답 메시지
+	ErrorCode string    `json:"errorCode"` // 에러 코드 (다국어 처리용)
+	Config    SPVConfig `json:"config"`    // 적용된 설정
+}
+
+// GetSPVConfig 현재 SPV 설정 반환
+func (a *App) GetSPVConfig() SPVConfig {
+	a.configMu.RLock()
+	defer a.configMu.RUnlock()
+	return a.config.SPV
+}
+
+// SetSPVConfig SPV 설정 변경 및 설정 파일 저장
+func (a *App) SetSPVConfig(config SPVConfig) SPVConfigResponse {
+	a.configMu.RLock()
+	appConfig := a.config
+	a.configMu.RUnlock()
+
+	appConfig.SPV = config
+	if err := a.applyConfig(appConfig); err != nil {
+		return SPVConfigResponse{
+			Success:   false,
+			Message:   err.Error(),
+			ErrorCode: "SPV_CONFIG_INVALID",
+		}
+	}
+
+	if err := saveAppConfig(appConfig); err != nil {
+		return SPVConfigResponse{
+			Success: false,
+			Message: fmt.Sprintf("설정 파일 저장 실패: %v", err),
+			Config:  config,
+		}
+	}
+
+	return SPVConfigResponse{
+		Success: true,
+		Message: "SPV 설정이 저장되었습니다",
+		Config:  config,
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// hashPair 두 노드를 이어 붙여 부모 노드 계산
+func hashPair(left, right chainhash.Hash) chainhash.Hash {
+	var pair [chainhash.HashSize * 2]byte
+	copy(pair[:chainhash.HashSize], left[:])
+	copy(pair[chainhash.HashSize:], right[:])
+	return chainhash.DoubleHashH(pair[:])
+}
+
+// merkleBranch 잎 노드 목록의 Merkle 루트와 pos 위치의 증명 경로 (홀수 개면 마지막 노드를 복제)
+func merkleBranch(leaves []chainhash.Hash, pos int) (chainhash.Hash, *merkleProof) {
+	proof := &merkleProof{Pos: pos}
+	level := append([]chainhash.Hash(nil), leaves...)
+	for len(level) > 1 {
+		if len(level)%2 == 1 {
+			level = append(level, level[len(level)-1])
+		}
+		proof.Merkle = append(proof.Merkle, level[pos^1].String())
+		next := make([]chainhash.Hash, 0, len(level)/2)
+		for i := 0; i < len(level); i += 2 {
+			next = append(next, hashPair(level[i], level[i+1]))
+		}
+		level = next
+		pos >>= 1
+	}
+	return level[0], proof
+}
+
+func serializeTx(t *testing.T, tx *wire.MsgTx) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func testCoinbaseTx() *wire.MsgTx {
+	tx := wire.NewMsgTx(1)
+	tx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Index: wire.MaxPrevOutIndex},
+		SignatureScript:  []byte{0x03, 0x50, 0xf8, 0x0c},
+		Sequence:         wire.MaxTxInSequenceNum,
+	})
+	tx.AddTxOut(&wire.TxOut{Value: 312_500_000, PkScript: []byte{0x51}})
+	return tx
+}
+
+func testSpendTx(n byte) *wire.MsgTx {
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{n}}, Sequence: wire.MaxTxInSequenceNum})
+	tx.AddTxOut(&wire.TxOut{Value: int64(n) * 1_000, PkScript: []byte{0x51}})
+	return tx
+}
+
+func TestVerifyMerkleProof(t *testing.T) {
+	coinbase := testCoinbaseTx()
+	txs := []*wire.MsgTx{coinbase, testSpendTx(1), testSpendTx(2)}
+	leaves := make([]chainhash.Hash, len(txs))
+	for i, tx := range txs {
+		leaves[i] = tx.TxHash()
+	}
+
+	root, coinbaseProof := merkleBranch(leaves, 0)
+	depth, err := coinbaseTreeDepth(serializeTx(t, coinbase), coinbaseProof, root)
+	if err != nil || depth != 2 {
+		t.Fatalf("coinbaseTreeDepth = %d, %v, want 2", depth, err)
+	}
+
+	for pos := range txs {
+		_, proof := merkleBranch(leaves, pos)
+		if err := verifyMerkleProof(leaves[pos].String(), proof, root, depth); err != nil {
+			t.Errorf("pos %d: %v", pos, err)
+		}
+	}
+
+	_, proof := merkleBranch(leaves, 2)
+	if err := verifyMerkleProof(leaves[1].String(), proof, root, depth); err == nil {
+		t.Error("proof for a different transaction should fail")
+	}
+
+	// 블록의 첫 거래가 코인베이스가 아니면 트리 깊이를 믿을 수 없음
+	if _, err := coinbaseTreeDepth(serializeTx(t, txs[1]), coinbaseProof, root); err == nil {
+		t.Error("coinbaseTreeDepth should reject a non-coinbase transaction")
+	}
+	_, spendProof := merkleBranch(leaves, 1)
+	if _, err := coinbaseTreeDepth(serializeTx(t, coinbase), spendProof, root); err == nil {
+		t.Error("coinbaseTreeDepth should reject a proof that is not for position 0")
+	}
+}
+
+func TestVerifyMerkleProofRejectsInnerNodeLeaf(t *testing.T) {
+	// 64바이트 거래 left||right는 내부 노드처럼 해석될 수 있음
+	left, right := chainhash.Hash{0xaa}, chainhash.Hash{0xbb}
+	leaves := []chainhash.Hash{testCoinbaseTx().TxHash(), hashPair(left, right)}
+	root, coinbaseProof := merkleBranch(leaves, 0)
+	depth := len(coinbaseProof.Merkle)
+
+	// right를 거래 ID로 주장하는 가짜 증명 (64바이트 거래 아래로 한 단계 더 내려감)
+	forged := &merkleProof{Pos: 3, Merkle: []string{left.String(), leaves[0].String()}}
+	if err := verifyMerkleProof(right.String(), forged, root, len(forged.Merkle)); err != nil {
+		t.Fatalf("forged proof should hash to the block root without the depth check: %v", err)
+	}
+	err := verifyMerkleProof(right.String(), forged, root, depth)
+	if err == nil || !strings.Contains(err.Error(), "트리 깊이") {
+		t.Errorf("verifyMerkleProof with depth %d = %v, want tree depth rejection", depth, err)
+	}
+}
@@ -0,0 +1,266 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/wire"
+)
+
+const (
+	// pendingTransactionTTL 확인 토큰 유효 시간 (만료 후에는 다시 준비해야 함)
+	pendingTransactionTTL = 10 * time.Minute
+
+	// highFeeRateWarning 경고를 표시할 수수료율 기준 (sat/vB)
+	highFeeRateWarning = 200.0
+)
+
+// TransactionPlanInput 거래 계획의 입력 정보
+type TransactionPlanInput struct {
+	TxID      string `json:"txid"`      // 이전 거래 ID
+	Vout      int    `json:"vout"`      // 이전 출력 인덱스
+	AmountSat int64  `json:"amountSat"` // 입력 금액 (satoshi)
+	Sequence  uint32 `json:"sequence"`  // 입력 시퀀스 (RBF 신호 확인용)
+}
+
+// TransactionPlanOutput 거래 계획의 출력 정보
+type TransactionPlanOutput struct {
+	Address   string `json:"address"`   // 받는 주소
+	AmountSat int64  `json:"amountSat"` // 출력 금액 (satoshi)
+	Type      string `json:"type"`      // 출력 종류 (recipient, developer_fee, change)
+	IsChange  bool   `json:"isChange"`  // 거스름돈 출력 여부
+	Label     string `json:"label"`     // 메모 (일괄 전송 라벨 등)
+}
+
+// TransactionWarning 사용자 확인 시 표시할 경고
+type TransactionWarning struct {
+	Code    string `json:"code"`    // 경고 코드 (다국어 처리용)
+	Message string `json:"message"` // 경고 메시지
+}
+
+// PrepareTransactionResponse 거래 미리보기 응답 구조체
+type PrepareTransactionResponse struct {
+	Success           bool                    `json:"success"`           // 성공 여부
+	Message           string                  `json:"message"`           // 응답 메시지
+	ErrorCode         string                  `json:"errorCode"`         // 에러 코드 (다국어 처리용)
+	ConfirmationToken string                  `json:"confirmationToken"` // 전송 시 제출할 일회용 확인 토큰
+	ExpiresAt         string                  `json:"expiresAt"`         // 토큰 만료 시각 (RFC3339)
+	Inputs            []TransactionPlanInput  `json:"inputs"`            // 선택된 입력 목록
+	Outputs           []TransactionPlanOutput `json:"outputs"`           // 모든 출력 목록
+	TotalInputSat     int64                   `json:"totalInputSat"`     // 입력 합계 (satoshi)
+	AmountSat         int64                   `json:"amountSat"`         // 전송 금액 (satoshi)
+	ChangeSat         int64                   `json:"changeSat"`         // 거스름돈 (satoshi)
+	FeeSat            int64                   `json:"feeSat"`            // 실제 채굴자 수수료 (satoshi)
+	DeveloperFeeSat   int64                   `json:"developerFeeSat"`   // 개발자 수수료 (satoshi)
+	FeeRate           float64                 `json:"feeRate"`           // 수수료율 (sat/vB)
+	VSize             int64                   `json:"vsize"`             // 예상 거래 크기 (vbyte)
+	RBF               bool                    `json:"rbf"`               // RBF(BIP125) 신호 여부
+	Warnings          []TransactionWarning    `json:"warnings"`          // 확인 시 표시할 경고 목록
+	Discrepancies     []QuorumDiscrepancy     `json:"discrepancies"`     // 교차 검증 불일치 항목 (실패 시)
+}
+
+// pendingTransaction 사용자 확인 대기 중인 서명 전 거래 계획
+type pendingTransaction struct {
+	tx              *wire.MsgTx
+	walletAddress   string
+	inputs          []UTXO
+	outputs         []TransactionPlanOutput
+	prevOutScripts  [][]byte
+	prevOutValues   []int64
+	totalInputSat   int64
+	amountSat       int64
+	developerFeeSat int64
+	minerFeeSat     int64
+	absorbedSat     int64 // 더스트 거스름돈이 수수료로 흡수된 금액
+	rbf             bool
+	expiresAt       time.Time
+}
+
+// storePendingTransaction 거래 계획을 저장하고 일회용 확인 토큰 발급
+func (a *App) storePendingTransaction(plan *pendingTransaction) (string, error) {
+	tokenBytes := make([]byte, 16)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	a.pendingMu.Lock()
+	defer a.pendingMu.Unlock()
+
+	// 만료된 계획 정리
+	now := time.Now()
+	for key, pending := range a.pendingTxs {
+		if now.After(pending.expiresAt) {
+			delete(a.pendingTxs, key)
+		}
+	}
+
+	plan.expiresAt = now.Add(pendingTransactionTTL)
+	a.pendingTxs[token] = plan
+	return token, nil
+}
+
+// takePendingTransaction 확인 토큰에 해당하는 거래 계획을 꺼냄 (한 번만 사용 가능)
+func (a *App) takePendingTransaction(token, walletAddress string) (*pendingTransaction, error) {
+	if token == "" {
+		return nil, fmt.Errorf("거래 확인이 필요합니다. 먼저 거래를 미리보기 해주세요")
+	}
+
+	a.pendingMu.Lock()
+	plan, ok := a.pendingTxs[token]
+	delete(a.pendingTxs, token)
+	a.pendingMu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("유효하지 않거나 이미 사용된 확인 토큰입니다")
+	}
+	if time.Now().After(plan.expiresAt) {
+		return nil, fmt.Errorf("확인 토큰이 만료되었습니다. 거래를 다시 미리보기 해주세요")
+	}
+	if plan.walletAddress != walletAddress {
+		return nil, fmt.Errorf("확인한 거래의 지갑과 현재 지갑이 다릅니다")
+	}
+
+	return plan, nil
+}
+
+// restorePendingTransaction 전송에 실패한 거래 계획을 같은 토큰으로 되돌려 놓음 (만료 전인 경우만)
+func (a *App) restorePendingTransaction(token string, plan *pendingTransaction) bool {
+	if time.Now().After(plan.expiresAt) {
+		return false
+	}
+
+	a.pendingMu.Lock()
+	defer a.pendingMu.Unlock()
+	a.pendingTxs[token] = plan
+	return true
+}
+
+// planWarnings 거래 계획에서 사용자에게 알릴 경고 생성 (lowFeeRate는 백엔드의 가장 낮은 예상 수수료율, 모르면 0)
+func planWarnings(plan *pendingTransaction, feeRate, lowFeeRate float64) []TransactionWarning {
+	warnings := []TransactionWarning{}
+
+	if plan.absorbedSat > 0 {
+		warnings = append(warnings, TransactionWarning{
+			Code:    "CHANGE_ABSORBED_AS_FEE",
+			Message: fmt.Sprintf("거스름돈 %d satoshi가 더스트 한도보다 작아 수수료에 포함됩니다", plan.absorbedSat),
+		})
+	}
+
+	if feeRate > highFeeRateWarning {
+		warnings = append(warnings, TransactionWarning{
+			Code:    "HIGH_FEE_RATE",
+			Message: fmt.Sprintf("수수료율이 매우 높습니다 (%.1f sat/vB)", feeRate),
+		})
+	}
+
+	if lowFeeRate > 0 && feeRate < lowFeeRate {
+		warnings = append(warnings, TransactionWarning{
+			Code:    "LOW_FEE_RATE",
+			Message: fmt.Sprintf("수수료율(%.1f sat/vB)이 백엔드의 가장 낮은 예상 수수료율(%.1f sat/vB)보다 낮아 전파되지 않거나 오래 확인되지 않을 수 있습니다", feeRate, lowFeeRate),
+		})
+	}
+
+	if plan.amountSat > 0 && plan.minerFeeSat*10 > plan.amountSat {
+		warnings = append(warnings, TransactionWarning{
+			Code:    "FEE_EXCEEDS_10_PERCENT",
+			Message: "수수료가 전송 금액의 10%를 초과합니다",
+		})
+	}
+
+	for _, output := range plan.outputs {
+		if !output.IsChange && output.Address == plan.walletAddress {
+			warnings = append(warnings, TransactionWarning{
+				Code:    "SEND_TO_SELF",
+				Message: "받는 주소가 현재 지갑 주소와 같습니다",
+			})
+			break
+		}
+	}
+
+	if !plan.rbf {
+		warnings = append(warnings, TransactionWarning{
+			Code:    "RBF_DISABLED",
+			Message: "RBF가 비활성화되어 전송 후 수수료를 올릴 수 없습니다",
+		})
+	}
+
+	return warnings
+}
+
+// lowestFeeRate 백엔드의 가장 낮은 예상 수수료율 (조회 실패 시 0)
+func (a *App) lowestFeeRate() float64 {
+	estimates, err := a.chain().FeeEstimates()
+	if err != nil {
+		return 0
+	}
+	var lowest float64
+	for _, rate := range estimates {
+		if rate > 0 && (lowest == 0 || rate < lowest) {
+			lowest = rate
+		}
+	}
+	return lowest
+}
+
+// PrepareTransaction 서명 전에 거래 계획을 구성하여 미리보기로 반환
+// 반환된 확인 토큰을 SendBitcoinTransaction에 제출하면 미리보기와 동일한 거래가 서명됨
+func (a *App) PrepareTransaction(request SendBitcoinRequest) PrepareTransactionResponse {
+	plan, failure := a.buildTransactionPlan(request)
+	if plan == nil {
+		return PrepareTransactionResponse{
+			Success:       false,
+			Message:       failure.Message,
+			ErrorCode:     failure.ErrorCode,
+			Discrepancies: failure.Discrepancies,
+		}
+	}
+
+	token, err := a.storePendingTransaction(plan)
+	if err != nil {
+		return PrepareTransactionResponse{
+			Success: false,
+			Message: fmt.Sprintf("확인 토큰 생성 실패: %v", err),
+		}
+	}
+
+	vsize := estimateVSize(plan.tx)
+	feeRate := float64(plan.minerFeeSat) / float64(vsize)
+
+	inputs := make([]TransactionPlanInput, len(plan.inputs))
+	for i, utxo := range plan.inputs {
+		inputs[i] = TransactionPlanInput{
+			TxID:      utxo.TxID,
+			Vout:      utxo.Vout,
+			AmountSat: utxo.Value,
+			Sequence:  plan.tx.TxIn[i].Sequence,
+		}
+	}
+
+	var changeSat int64
+	for _, output := range plan.outputs {
+		if output.IsChange {
+			changeSat += output.AmountSat
+		}
+	}
+
+	return PrepareTransactionResponse{
+		Success:           true,
+		Message:           "거래 미리보기가 준비되었습니다",
+		ConfirmationToken: token,
+		ExpiresAt:         plan.expiresAt.Format(time.RFC3339),
+		Inputs:            inputs,
+		Outputs:           plan.outputs,
+		TotalInputSat:     plan.totalInputSat,
+		AmountSat:         plan.amountSat,
+		ChangeSat:         changeSat,
+		FeeSat:            plan.minerFeeSat,
+		DeveloperFeeSat:   plan.developerFeeSat,
+		FeeRate:           feeRate,
+		VSize:             vsize,
+		RBF:               plan.rbf,
+		Warnings:          append(planWarnings(plan, feeRate, a.lowestFeeRate()), addressBookWarnings(request, plan.outputs)...),
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// errPrevOutMismatch 서버가 준 이전 출력 정보가 원본 거래와 다를 때의 오류
+var errPrevOutMismatch = errors.New("이전 출력 검증 실패")
+
+// parseRawTransaction 원본 거래를 파싱하고 로컬에서 계산한 txid 반환
+func parseRawTransaction(raw []byte) (*wire.MsgTx, string, error) {
+	tx := wire.NewMsgTx(wire.TxVersion)
+	if err := tx.Deserialize(bytes.NewReader(raw)); err != nil {
+		return nil, "", fmt.Errorf("거래 파싱 실패: %v", err)
+	}
+	return tx, tx.TxHash().String(), nil
+}
+
+// verifyPrevOuts 서명 전에 모든 입력의 이전 거래 원본을 받아 검증하고 서명용 fetcher 반환
+// 원본 거래의 txid를 로컬에서 다시 계산하여 서버가 보낸 데이터가 위조되지 않았는지 확인하고,
+// 참조하는 출력의 금액과 스크립트가 거래 계획(prevOutValues, prevOutScripts)과 같은지 확인함
+func (a *App) verifyPrevOuts(tx *wire.MsgTx, prevOutScripts [][]byte, prevOutValues []int64) (*txscript.MultiPrevOutFetcher, error) {
+	txids := make([]string, len(tx.TxIn))
+	for i, txIn := range tx.TxIn {
+		txids[i] = txIn.PreviousOutPoint.Hash.String()
+	}
+
+	raws, err := a.fetchRawTransactions(txids)
+	if err != nil {
+		return nil, fmt.Errorf("이전 거래 조회 실패: %w", err)
+	}
+
+	prevTxs := make(map[string]*wire.MsgTx, len(raws))
+	for txid, raw := range raws {
+		prevTx, computed, err := parseRawTransaction(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", errPrevOutMismatch, txid, err)
+		}
+		if computed != txid {
+			return nil, fmt.Errorf("%w: 서버가 보낸 거래 %s의 실제 txid가 %s입니다", errPrevOutMismatch, txid, computed)
+		}
+		prevTxs[txid] = prevTx
+	}
+
+	fetcher := txscript.NewMultiPrevOutFetcher(nil)
+	for i, txIn := range tx.TxIn {
+		outPoint := txIn.PreviousOutPoint
+		prevTx := prevTxs[outPoint.Hash.String()]
+		if int(outPoint.Index) >= len(prevTx.TxOut) {
+			return nil, fmt.Errorf("%w: %s 출력이 존재하지 않습니다", errPrevOutMismatch, outPoint)
+		}
+
+		prevOut := prevTx.TxOut[outPoint.Index]
+		if prevOut.Value != prevOutValues[i] {
+			return nil, fmt.Errorf("%w: %s 금액이 다릅니다 (서버: %d, 원본: %d satoshi)",
+				errPrevOutMismatch, outPoint, prevOutValues[i], prevOut.Value)
+		}
+		if !bytes.Equal(prevOut.PkScript, prevOutScripts[i]) {
+			return nil, fmt.Errorf("%w: %s 출력이 이 지갑의 스크립트가 아닙니다", errPrevOutMismatch, outPoint)
+		}
+
+		fetcher.AddPrevOut(outPoint, prevOut)
+	}
+
+	return fetcher, nil
+}
+
+// prevOutErrorCode 이전 출력 검증 오류의 에러 코드
+func prevOutErrorCode(err error) string {
+	if errors.Is(err, errPrevOutMismatch) {
+		return "PREVOUT_MISMATCH"
+	}
+	return backendErrorCode(err)
+}
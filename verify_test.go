@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// testWalletWIF testWalletAddress의 개인키 (개인키 1, 공개키는 생성점 G)
+func testWalletWIF(t *testing.T) string {
+	t.Helper()
+	var key [32]byte
+	key[31] = 1
+	privKey, _ := btcec.PrivKeyFromBytes(key[:])
+	wif, err := btcutil.NewWIF(privKey, &chaincfg.MainNetParams, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return wif.String()
+}
+
+func TestSendRejectsTamperedPrevOuts(t *testing.T) {
+	walletScript := walletScriptFromState(&filterState{Address: testWalletAddress})
+	funding := spendingTx(wire.OutPoint{Hash: chainhash.Hash{1}}, 100_000, walletScript)
+	fundingID := funding.TxHash().String()
+	other := spendingTx(wire.OutPoint{Hash: chainhash.Hash{2}}, 100_000, walletScript)
+
+	tests := []struct {
+		name     string
+		utxo     int64  // 백엔드가 알려준 UTXO 금액
+		raw      []byte // 백엔드가 funding의 원본으로 보낸 거래
+		wantCode string
+	}{
+		{name: "honest backend", utxo: 100_000, raw: serializeTx(t, funding)},
+		{name: "inflated value", utxo: 150_000, raw: serializeTx(t, funding), wantCode: "PREVOUT_MISMATCH"},
+		{name: "different transaction", utxo: 100_000, raw: serializeTx(t, other), wantCode: "PREVOUT_MISMATCH"},
+	}
+
+	for _, tt := range tests {
+		backend := newFakeBackend()
+		backend.utxos[testWalletAddress] = []UTXO{{TxID: fundingID, Vout: 0, Value: tt.utxo, Status: TxStatus{Confirmed: true, BlockHeight: 849000}}}
+		backend.raw[fundingID] = tt.raw
+		app := newTestApp(backend)
+
+		request := SendBitcoinRequest{
+			WalletData:       WalletData{Address: testWalletAddress, PrivateKeyWIF: testWalletWIF(t)},
+			RecipientAddress: testRecipientAddress,
+			AmountSat:        50_000,
+			FeeSatoshi:       2_000,
+		}
+		plan := app.PrepareTransaction(request)
+		if !plan.Success {
+			t.Fatalf("%s: PrepareTransaction: %s", tt.name, plan.Message)
+		}
+		request.ConfirmationToken = plan.ConfirmationToken
+		response := app.SendBitcoinTransaction(request)
+
+		if tt.wantCode == "" {
+			if !response.Success || len(backend.broadcasts) != 1 {
+				t.Errorf("%s: SendBitcoinTransaction = %+v, want one broadcast", tt.name, response)
+			}
+			continue
+		}
+		if response.Success || response.ErrorCode != tt.wantCode {
+			t.Errorf("%s: SendBitcoinTransaction = %+v, want %s", tt.name, response, tt.wantCode)
+		}
+		if len(backend.broadcasts) != 0 {
+			t.Errorf("%s: transaction was broadcast despite the prevout mismatch", tt.name)
+		}
+	}
+}